@@ -0,0 +1,70 @@
+// Package dailyreport aggregates a batch of completed trips into the
+// summary statistics a fleet dashboard would want: total trips, total fare,
+// total distance, average fare, and the busiest hour of the day.
+package dailyreport
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+// DailyReport summarizes a batch of models.ProcessingResult values.
+type DailyReport struct {
+	TotalTrips    int
+	TotalFare     decimal.Decimal
+	TotalDistance decimal.Decimal
+	AverageFare   decimal.Decimal
+
+	// BusiestHour is the hour (0-23) with the most trip starts, determined
+	// from the first record's timestamp in each trip. -1 if no trip in the
+	// batch has any records.
+	BusiestHour int
+}
+
+// String implements the Stringer interface for debugging
+func (dr DailyReport) String() string {
+	return fmt.Sprintf("DailyReport{TotalTrips: %d, TotalFare: %s, TotalDistance: %s, AverageFare: %s, BusiestHour: %d}",
+		dr.TotalTrips, dr.TotalFare.String(), dr.TotalDistance.String(), dr.AverageFare.String(), dr.BusiestHour)
+}
+
+// GenerateDailyReport aggregates results into a DailyReport. A result with
+// no records still contributes to TotalTrips/TotalFare/TotalDistance but has
+// no first timestamp, so it doesn't count toward BusiestHour. Ties for
+// busiest hour are broken in favor of the earlier hour.
+func GenerateDailyReport(results []models.ProcessingResult) DailyReport {
+	report := DailyReport{
+		TotalFare:     decimal.Zero,
+		TotalDistance: decimal.Zero,
+		AverageFare:   decimal.Zero,
+		BusiestHour:   -1,
+	}
+
+	var hourCounts [24]int
+	for _, result := range results {
+		report.TotalTrips++
+		report.TotalFare = report.TotalFare.Add(result.Calculation.TotalFare)
+
+		if len(result.Records) > 0 {
+			first := result.Records[0]
+			last := result.Records[len(result.Records)-1]
+			report.TotalDistance = report.TotalDistance.Add(last.Distance.Sub(first.Distance))
+			hourCounts[first.Timestamp.Hour()]++
+		}
+	}
+
+	if report.TotalTrips > 0 {
+		report.AverageFare = report.TotalFare.Div(decimal.NewFromInt(int64(report.TotalTrips)))
+	}
+
+	busiestCount := 0
+	for hour, count := range hourCounts {
+		if count > busiestCount {
+			busiestCount = count
+			report.BusiestHour = hour
+		}
+	}
+
+	return report
+}
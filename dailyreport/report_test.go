@@ -0,0 +1,108 @@
+package dailyreport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func mustParseTime(value string) time.Time {
+	t, err := time.Parse("15:04:05.000", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestGenerateDailyReport(t *testing.T) {
+	t.Run("aggregates several trips and finds the busiest hour", func(t *testing.T) {
+		results := []models.ProcessingResult{
+			{
+				Records: []models.DistanceRecord{
+					{Timestamp: mustParseTime("08:00:00.000"), Distance: decimal.NewFromInt(10000000)},
+					{Timestamp: mustParseTime("08:10:00.000"), Distance: decimal.NewFromInt(10002000)},
+				},
+				Calculation: models.FareCalculation{TotalFare: decimal.NewFromInt(700)},
+			},
+			{
+				Records: []models.DistanceRecord{
+					{Timestamp: mustParseTime("08:30:00.000"), Distance: decimal.NewFromInt(20000000)},
+					{Timestamp: mustParseTime("08:45:00.000"), Distance: decimal.NewFromInt(20003000)},
+				},
+				Calculation: models.FareCalculation{TotalFare: decimal.NewFromInt(900)},
+			},
+			{
+				Records: []models.DistanceRecord{
+					{Timestamp: mustParseTime("19:00:00.000"), Distance: decimal.NewFromInt(30000000)},
+					{Timestamp: mustParseTime("19:05:00.000"), Distance: decimal.NewFromInt(30001000)},
+				},
+				Calculation: models.FareCalculation{TotalFare: decimal.NewFromInt(500)},
+			},
+		}
+
+		report := GenerateDailyReport(results)
+
+		if report.TotalTrips != 3 {
+			t.Errorf("TotalTrips = %d, want 3", report.TotalTrips)
+		}
+		wantFare := decimal.NewFromInt(2100)
+		if !report.TotalFare.Equal(wantFare) {
+			t.Errorf("TotalFare = %s, want %s", report.TotalFare, wantFare)
+		}
+		wantDistance := decimal.NewFromInt(6000)
+		if !report.TotalDistance.Equal(wantDistance) {
+			t.Errorf("TotalDistance = %s, want %s", report.TotalDistance, wantDistance)
+		}
+		wantAverage := decimal.NewFromInt(700)
+		if !report.AverageFare.Equal(wantAverage) {
+			t.Errorf("AverageFare = %s, want %s", report.AverageFare, wantAverage)
+		}
+		if report.BusiestHour != 8 {
+			t.Errorf("BusiestHour = %d, want 8", report.BusiestHour)
+		}
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		report := GenerateDailyReport(nil)
+
+		if report.TotalTrips != 0 {
+			t.Errorf("TotalTrips = %d, want 0", report.TotalTrips)
+		}
+		if !report.AverageFare.IsZero() {
+			t.Errorf("AverageFare = %s, want 0", report.AverageFare)
+		}
+		if report.BusiestHour != -1 {
+			t.Errorf("BusiestHour = %d, want -1 (no records)", report.BusiestHour)
+		}
+	})
+
+	t.Run("a trip with no records still counts toward fare totals", func(t *testing.T) {
+		results := []models.ProcessingResult{
+			{Calculation: models.FareCalculation{TotalFare: decimal.NewFromInt(500)}},
+		}
+
+		report := GenerateDailyReport(results)
+
+		if report.TotalTrips != 1 {
+			t.Errorf("TotalTrips = %d, want 1", report.TotalTrips)
+		}
+		if report.BusiestHour != -1 {
+			t.Errorf("BusiestHour = %d, want -1 (no records with a timestamp)", report.BusiestHour)
+		}
+	})
+
+	t.Run("ties for busiest hour favor the earlier hour", func(t *testing.T) {
+		results := []models.ProcessingResult{
+			{Records: []models.DistanceRecord{{Timestamp: mustParseTime("09:00:00.000"), Distance: decimal.Zero}}},
+			{Records: []models.DistanceRecord{{Timestamp: mustParseTime("14:00:00.000"), Distance: decimal.Zero}}},
+		}
+
+		report := GenerateDailyReport(results)
+
+		if report.BusiestHour != 9 {
+			t.Errorf("BusiestHour = %d, want 9 (earlier hour on tie)", report.BusiestHour)
+		}
+	})
+}
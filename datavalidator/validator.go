@@ -4,8 +4,12 @@ package datavalidator
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"time"
 
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/loggingsystem"
 	"golang-taxi-fare/models"
 )
 
@@ -16,6 +20,45 @@ type ValidationError struct {
 	RecordIndex int    // Index of the record in sequence that failed validation
 	Field       string // Field that failed validation (timestamp, distance, etc.)
 	Input       string // Input data that caused the error
+
+	// DisplayIndexBase is added to RecordIndex when rendering Error(), so
+	// callers can show 1-based record numbers (matching how users count
+	// lines in their input file) without changing the 0-based RecordIndex
+	// used internally. Set via DataValidator.DisplayIndexBase; the zero
+	// value preserves the original 0-based display.
+	DisplayIndexBase int
+
+	// Severity classifies how serious the issue is. The zero value,
+	// SeverityError, preserves the historical behavior of every
+	// ValidationError produced by this package: callers that abort
+	// processing on any returned error continue to do so unchanged.
+	// SeverityWarning marks an issue a caller may choose to surface
+	// separately (e.g. to a dedicated warning stream) and continue
+	// processing past, rather than treating as fatal. This package itself
+	// never constructs a SeverityWarning error; callers set it explicitly
+	// when they want a particular check to be advisory rather than fatal.
+	Severity ValidationSeverity
+}
+
+// ValidationSeverity classifies how serious a ValidationError is.
+type ValidationSeverity int
+
+const (
+	// SeverityError marks an issue as fatal to processing (the default).
+	SeverityError ValidationSeverity = iota
+	// SeverityWarning marks an issue as advisory: worth surfacing, but not
+	// fatal to processing.
+	SeverityWarning
+)
+
+// String returns a human-readable description of the validation severity
+func (vs ValidationSeverity) String() string {
+	switch vs {
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
 }
 
 // ValidationErrorType categorizes different validation error types
@@ -37,8 +80,8 @@ const (
 // Error implements the error interface
 func (ve *ValidationError) Error() string {
 	if ve.RecordIndex >= 0 {
-		return fmt.Sprintf("validation error at record %d (%s): %s (input: %q)", 
-			ve.RecordIndex, ve.Type.String(), ve.Message, ve.Input)
+		return fmt.Sprintf("validation error at record %d (%s): %s (input: %q)",
+			ve.RecordIndex+ve.DisplayIndexBase, ve.Type.String(), ve.Message, ve.Input)
 	}
 	return fmt.Sprintf("validation error (%s): %s (input: %q)", 
 		ve.Type.String(), ve.Message, ve.Input)
@@ -117,25 +160,248 @@ func ConstraintError(recordIndex int, field string, message string, input interf
 	}
 }
 
+// Clock abstracts access to the current time so validation rules that compare
+// against "now" can be tested deterministically.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
+
+// realClock implements Clock using the system wall clock
+type realClock struct{}
+
+// Now returns the current system time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewRealClock creates a Clock backed by the system wall clock
+func NewRealClock() Clock {
+	return realClock{}
+}
+
+// CheckOrder controls the order in which ValidateSequence runs its per-record
+// and pairwise checks.
+type CheckOrder int
+
+const (
+	// PerRecordFirst validates every record individually before checking any
+	// pairwise sequence constraints (the historical behavior). This can
+	// report a later record's format/constraint error before an earlier
+	// pair's timing or mileage error, even though the earlier problem
+	// occurs first in the sequence.
+	PerRecordFirst CheckOrder = iota
+
+	// Interleaved validates each record together with its pairwise checks
+	// against the predecessor in a single forward pass, so the earliest
+	// positional problem in the sequence is always reported first.
+	Interleaved
+)
+
 // Validator defines the interface for data validation operations
 type Validator interface {
 	// ValidateRecord validates a single DistanceRecord for basic constraints
 	ValidateRecord(record models.DistanceRecord) error
-	
+
 	// ValidateSequence validates a complete sequence of DistanceRecord entries
 	ValidateSequence(records []models.DistanceRecord) error
 }
 
+// SequenceSorter is implemented by a Validator that may reorder records
+// before validating them (e.g. DataValidator with PreSortByTimestamp
+// enabled). Callers that go on to process records after ValidateSequence
+// succeeds should type-assert for this optional interface and substitute
+// SortSequence's result for their own copy, so later steps (fare
+// calculation, record output) see the same order validation actually
+// checked rather than the original, possibly out-of-order input.
+type SequenceSorter interface {
+	// SortSequence returns the order ValidateSequence would validate
+	// records in, without performing any validation itself. Returns
+	// records unchanged when no reordering applies.
+	SortSequence(records []models.DistanceRecord) []models.DistanceRecord
+}
+
 // DataValidator implements the Validator interface with comprehensive validation rules
 type DataValidator struct {
 	// MaxInterval defines the maximum allowed time interval between consecutive records
 	MaxInterval time.Duration
+
+	// MaxIntervalViolations tolerates isolated single-record dropouts: up to
+	// this many MaxInterval violations within a sequence are allowed through
+	// without failing validation. The (MaxIntervalViolations+1)th violation
+	// in a sequence fails as usual. The zero value (default) tolerates none,
+	// preserving the original behavior of failing on the first violation.
+	// Tolerated violations are not otherwise surfaced: ValidateSequence
+	// returns nil for them and does not report them as SeverityWarning
+	// errors either.
+	MaxIntervalViolations int
 	
 	// AllowIdenticalTimestamps determines if consecutive records can have identical timestamps
 	AllowIdenticalTimestamps bool
+
+	// MaxIdenticalTimestampRun, when non-zero, tolerates up to this many
+	// consecutive records that repeat their predecessor's timestamp (so up
+	// to MaxIdenticalTimestampRun+1 records may share one timestamp) even when
+	// AllowIdenticalTimestamps is false, for GPS sources that legitimately
+	// emit a handful of points with the same millisecond stamp. The run
+	// resets on the next record with a different timestamp; the
+	// (MaxIdenticalTimestampRun+1)th consecutive identical timestamp fails
+	// as usual. Has no effect when AllowIdenticalTimestamps is true (every
+	// run is already tolerated). The zero value (default) preserves the
+	// original behavior of failing on the first identical timestamp.
+	MaxIdenticalTimestampRun int
+
+	// RequireStrictlyIncreasingTime consolidates the decreasing-timestamp
+	// check and AllowIdenticalTimestamps into a single rule: when true, any
+	// consecutive pair with a timestamp that is equal to or earlier than its
+	// predecessor is rejected with one consistent message, and
+	// AllowIdenticalTimestamps is ignored entirely. When false (the
+	// default), the two checks apply independently as before: decreasing
+	// timestamps are always rejected, and identical timestamps are rejected
+	// only when AllowIdenticalTimestamps is false.
+	RequireStrictlyIncreasingTime bool
 	
 	// AllowIdenticalMileage determines if consecutive records can have identical mileage
 	AllowIdenticalMileage bool
+
+	// RejectFutureTimestamps determines if records dated after the current time
+	// (as reported by Clock) are rejected as a constraint violation. Timestamps
+	// parsed from the "hh:mm:ss.fff" layout carry no date component (year 0) and
+	// are never considered future, so this is a no-op until dated timestamps are
+	// in use.
+	RejectFutureTimestamps bool
+
+	// Clock supplies the current time for RejectFutureTimestamps comparisons
+	Clock Clock
+
+	// MaxDistance caps the plausible value of a single record's odometer
+	// reading. A record above this bound is rejected as a ConstraintError.
+	// The zero value disables the check. This is a per-record sanity bound,
+	// distinct from sequence-level total-distance checks.
+	MaxDistance decimal.Decimal
+
+	// CheckOrder controls whether ValidateSequence runs all per-record checks
+	// before all pairwise checks (PerRecordFirst, the default) or interleaves
+	// them to report the earliest positional problem (Interleaved).
+	CheckOrder CheckOrder
+
+	// PreSortByTimestamp stable-sorts a copy of the records by Timestamp
+	// before any other ValidateSequence check runs, so records delivered
+	// slightly out of order (e.g. by async logging) are tolerated instead of
+	// failing the decreasing-timestamp check. This changes behavior
+	// meaningfully — a sequence that was previously rejected as
+	// out-of-order may now pass — so it defaults to false. The original
+	// records slice passed to ValidateSequence is never modified; sorting
+	// operates on a copy.
+	PreSortByTimestamp bool
+
+	// RelaxLastInterval, when true, exempts only the final consecutive pair
+	// in the sequence from the MaxInterval check, tolerating an arbitrarily
+	// large gap between the second-to-last and last record. This addresses
+	// feeds where the vehicle stops before its final GPS ping arrives,
+	// producing a large trailing interval that is otherwise indistinguishable
+	// from a genuine mid-trip dropout but doesn't actually indicate bad
+	// positional coverage. Only the last pair is exempted — an equally large
+	// interior gap still fails MaxInterval as usual. Does not apply to
+	// LongestValidRun, which scans arbitrary windows rather than validating
+	// a single trip's end. Defaults to false, preserving the original
+	// behavior of never exempting any pair.
+	RelaxLastInterval bool
+
+	// IntervalJitterBudget, when non-zero, replaces the MaxIntervalViolations
+	// tolerance with a cumulative one: individual gaps may exceed MaxInterval
+	// by any amount, as long as the sum of every gap's excess-over-MaxInterval
+	// across the sequence stays within this budget. The first gap whose
+	// excess pushes the running total over the budget fails validation,
+	// rather than the (MaxIntervalViolations+1)th violation. This models
+	// feeds that are bursty-but-acceptable overall rather than one that
+	// tolerates a fixed count of isolated dropouts. Has no effect on
+	// MaxIntervalViolations when set (they are alternative tolerance
+	// schemes, not combined); MaxIntervalViolations' whole-violation counting
+	// is used when this is zero (the default), preserving the original
+	// behavior.
+	IntervalJitterBudget time.Duration
+
+	// RejectZeroTotalDistance, when true, fails ValidateSequence with a
+	// ConstraintError whenever the first and last record in the sequence
+	// report identical distance, indicating the vehicle never moved (e.g. a
+	// logging error or an aborted pickup). Defaults to false, since a
+	// genuine zero-distance, base-fare-only trip (the passenger boards and
+	// immediately cancels) is a valid outcome that this validator should not
+	// reject by default. Single-record sequences are unaffected, since they
+	// are validated by ValidateRecord instead of this check.
+	RejectZeroTotalDistance bool
+
+	// RequireTimestamp determines whether a zero timestamp is rejected as a
+	// format error. When false, ValidateSequence also skips all pairwise
+	// timing checks (non-decreasing order, identical-timestamp policy,
+	// MaxInterval) entirely, since timestamps can no longer be trusted to
+	// establish a reliable ordering. Defaults to true.
+	RequireTimestamp bool
+
+	// RequireDistance determines whether ValidateSequence runs its pairwise
+	// mileage progression checks (non-decreasing order, identical-mileage
+	// policy). When false, those checks are skipped entirely; a record's
+	// basic per-record distance sanity checks (non-negative, MaxDistance)
+	// still apply regardless, since a negative or implausible odometer
+	// reading is never valid input. Defaults to true.
+	RequireDistance bool
+
+	// SpeedOutlierFactor, when non-zero, enables a sequence-level relative
+	// speed outlier check: ValidateSequence computes the implied speed
+	// (distance/time) of every segment, takes the median across the whole
+	// sequence, then rejects any segment whose speed deviates from that
+	// median by more than this fraction (e.g. decimal.NewFromFloat(0.5) for
+	// 50%). This is a relative check only; this codebase has no flat
+	// per-segment speed limit to compose it with. The zero value disables
+	// the check. It only runs when both RequireTimestamp and RequireDistance
+	// are true, and skips zero-duration segments (AllowIdenticalTimestamps
+	// and RequireStrictlyIncreasingTime already govern those).
+	SpeedOutlierFactor decimal.Decimal
+
+	// DisplayIndexBase is added to RecordIndex when a ValidationError
+	// returned by ValidateRecord or ValidateSequence renders its Error()
+	// message, so messages can read "record 1" instead of "record 0" for
+	// users who count lines in their input file starting at 1. The internal
+	// RecordIndex stays 0-based regardless. Defaults to 0, preserving the
+	// original 0-based messages.
+	DisplayIndexBase int
+
+	// MaxRecords caps how many records a single sequence may contain, as a
+	// data-contract constraint for downstream systems with their own
+	// per-trip point limits. A sequence exceeding the cap fails as a
+	// SequenceError, distinct from any application-level memory cap, which
+	// would be reported separately. The zero value (default) leaves sequence
+	// length unbounded.
+	MaxRecords int
+
+	// RequireProgressTogether, when true, flags a consecutive pair whose time
+	// advance exceeds ProgressStallThreshold but whose mileage is exactly
+	// unchanged, as a suspicious ConstraintError: a vehicle that isn't moving
+	// for that long while still reporting GPS pings suggests a stuck sensor
+	// rather than a genuine stationary stop. This is independent of, and
+	// layered on top of, the separate timing (MaxInterval) and mileage
+	// (AllowIdenticalMileage) checks, which only look at one dimension at a
+	// time. Only runs when both RequireTimestamp and RequireDistance are
+	// true, since it needs both. Defaults to false.
+	RequireProgressTogether bool
+
+	// ProgressStallThreshold is the time advance beyond which
+	// RequireProgressTogether expects to see at least some mileage change.
+	// The zero value flags any nonzero time advance paired with zero
+	// mileage change once RequireProgressTogether is enabled. Ignored when
+	// RequireProgressTogether is false.
+	ProgressStallThreshold time.Duration
+
+	// OdometerMax, when non-zero, enables odometer-rollover detection: a
+	// mileage decrease that looks like a rollover (previous near
+	// OdometerMax, current near zero) is reinterpreted by
+	// validateMileageProgression and SegmentDistance as
+	// (OdometerMax - previous) + current distance travelled, instead of
+	// being rejected as a non-decreasing mileage violation. Defaults to
+	// zero (disabled), preserving the historical behavior of rejecting
+	// every mileage decrease.
+	OdometerMax decimal.Decimal
 }
 
 // NewValidator creates a new DataValidator with default settings
@@ -144,6 +410,10 @@ func NewValidator() Validator {
 		MaxInterval:              5 * time.Minute, // 5-minute maximum interval
 		AllowIdenticalTimestamps: true,            // Allow identical timestamps
 		AllowIdenticalMileage:    true,            // Allow identical mileage readings
+		RejectFutureTimestamps:   false,           // Future timestamps allowed by default
+		Clock:                    NewRealClock(),
+		RequireTimestamp:         true,
+		RequireDistance:          true,
 	}
 }
 
@@ -153,13 +423,68 @@ func NewValidatorWithOptions(maxInterval time.Duration, allowIdenticalTimestamps
 		MaxInterval:              maxInterval,
 		AllowIdenticalTimestamps: allowIdenticalTimestamps,
 		AllowIdenticalMileage:    allowIdenticalMileage,
+		Clock:                    NewRealClock(),
+		RequireTimestamp:         true,
+		RequireDistance:          true,
+	}
+}
+
+// NewValidatorFromEnv creates a DataValidator from NewValidator's defaults,
+// overriding MaxInterval, SpeedOutlierFactor, and MaxDistance from the
+// TAXIFARE_MAX_INTERVAL, TAXIFARE_MAX_SPEED, and TAXIFARE_MAX_DISTANCE
+// environment variables when present, so ops can tune ingestion limits
+// without a redeploy. TAXIFARE_MAX_SPEED feeds SpeedOutlierFactor, the
+// relative speed-outlier check; this codebase has no flat per-segment speed
+// cap to assign it to instead. A variable that is absent falls back to its
+// NewValidator default silently; one that is present but fails to parse
+// also falls back to the default, logging a warning.
+func NewValidatorFromEnv() Validator {
+	dv := NewValidator().(*DataValidator)
+	logger := loggingsystem.NewLogger().WithComponent("datavalidator")
+
+	if raw, ok := os.LookupEnv("TAXIFARE_MAX_INTERVAL"); ok {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			dv.MaxInterval = parsed
+		} else {
+			logger.Warn("invalid TAXIFARE_MAX_INTERVAL, using default", "value", raw, "error", err.Error())
+		}
+	}
+
+	if raw, ok := os.LookupEnv("TAXIFARE_MAX_SPEED"); ok {
+		if parsed, err := decimal.NewFromString(raw); err == nil {
+			dv.SpeedOutlierFactor = parsed
+		} else {
+			logger.Warn("invalid TAXIFARE_MAX_SPEED, using default", "value", raw, "error", err.Error())
+		}
+	}
+
+	if raw, ok := os.LookupEnv("TAXIFARE_MAX_DISTANCE"); ok {
+		if parsed, err := decimal.NewFromString(raw); err == nil {
+			dv.MaxDistance = parsed
+		} else {
+			logger.Warn("invalid TAXIFARE_MAX_DISTANCE, using default", "value", raw, "error", err.Error())
+		}
+	}
+
+	return dv
+}
+
+// withDisplayBase sets err's DisplayIndexBase to dv.DisplayIndexBase when err
+// is a *ValidationError, so its Error() message renders record indices
+// consistently with the validator's configured base.
+func (dv *DataValidator) withDisplayBase(err error) error {
+	if ve, ok := err.(*ValidationError); ok {
+		ve.DisplayIndexBase = dv.DisplayIndexBase
 	}
+	return err
 }
 
 // ValidateRecord validates a single DistanceRecord for basic constraints
-func (dv *DataValidator) ValidateRecord(record models.DistanceRecord) error {
-	// Validate timestamp is not zero
-	if record.Timestamp.IsZero() {
+func (dv *DataValidator) ValidateRecord(record models.DistanceRecord) (err error) {
+	defer func() { err = dv.withDisplayBase(err) }()
+
+	// Validate timestamp is not zero, unless timestamps aren't required
+	if dv.RequireTimestamp && record.Timestamp.IsZero() {
 		return FormatError(0, "timestamp", "timestamp cannot be zero", record.Timestamp)
 	}
 	
@@ -167,24 +492,106 @@ func (dv *DataValidator) ValidateRecord(record models.DistanceRecord) error {
 	if record.Distance.IsNegative() {
 		return ConstraintError(0, "distance", "distance cannot be negative", record.Distance)
 	}
-	
+
+	// Reject implausible odometer readings above the configured sanity bound
+	if !dv.MaxDistance.IsZero() && record.Distance.GreaterThan(dv.MaxDistance) {
+		return ConstraintError(0, "distance",
+			fmt.Sprintf("distance exceeds maximum allowed odometer value %s", dv.MaxDistance.String()),
+			record.Distance)
+	}
+
+	// Reject records dated after the current time, indicating clock skew.
+	// Only meaningful once timestamps carry a date component.
+	if dv.RejectFutureTimestamps && isDated(record.Timestamp) {
+		clock := dv.Clock
+		if clock == nil {
+			clock = NewRealClock()
+		}
+		if record.Timestamp.After(clock.Now()) {
+			return ConstraintError(0, "timestamp",
+				fmt.Sprintf("timestamp is in the future: %s", record.Timestamp.Format(time.RFC3339)),
+				record.Timestamp)
+		}
+	}
+
 	// Additional basic validation can be added here
-	
+
 	return nil
 }
 
-// ValidateSequence validates a complete sequence of DistanceRecord entries
-func (dv *DataValidator) ValidateSequence(records []models.DistanceRecord) error {
+// isDated reports whether a timestamp carries a real date component rather than
+// being parsed from a bare "hh:mm:ss.fff" time-of-day string (which defaults to
+// year 0)
+func isDated(t time.Time) bool {
+	return t.Year() > 0
+}
+
+// SortSequence implements SequenceSorter. When PreSortByTimestamp is
+// false, it returns records unchanged. Otherwise it returns a
+// stable-sorted-by-Timestamp copy, leaving the records slice passed in
+// untouched.
+func (dv *DataValidator) SortSequence(records []models.DistanceRecord) []models.DistanceRecord {
+	if !dv.PreSortByTimestamp {
+		return records
+	}
+	sorted := make([]models.DistanceRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+	return sorted
+}
+
+// ValidateSequence validates a complete sequence of DistanceRecord entries.
+// When RequireTimestamp is false, pairwise timing checks are skipped
+// entirely; when RequireDistance is false, pairwise mileage checks are
+// skipped entirely. Both apply regardless of CheckOrder: PerRecordFirst and
+// Interleaved only control the relative order in which the remaining checks
+// run, not which checks run.
+func (dv *DataValidator) ValidateSequence(records []models.DistanceRecord) (err error) {
+	defer func() { err = dv.withDisplayBase(err) }()
+
 	// Handle empty sequence
 	if len(records) == 0 {
 		return SequenceError("sequence cannot be empty", len(records))
 	}
-	
+
+	if dv.MaxRecords > 0 && len(records) > dv.MaxRecords {
+		return SequenceError(
+			fmt.Sprintf("sequence exceeds maximum allowed records (%d), got %d", dv.MaxRecords, len(records)),
+			len(records))
+	}
+
 	// Single record validation
 	if len(records) == 1 {
 		return dv.ValidateRecord(records[0])
 	}
-	
+
+	records = dv.SortSequence(records)
+
+	if dv.RejectZeroTotalDistance && records[0].Distance.Equal(records[len(records)-1].Distance) {
+		return ConstraintError(len(records)-1, "distance",
+			"trip shows no net movement between first and last record", records[len(records)-1].Distance)
+	}
+
+	if dv.CheckOrder == Interleaved {
+		err = dv.validateSequenceInterleaved(records)
+	} else {
+		err = dv.validateSequencePerRecordFirst(records)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !dv.SpeedOutlierFactor.IsZero() && dv.RequireTimestamp && dv.RequireDistance {
+		return dv.validateSpeedOutliers(records)
+	}
+	return nil
+}
+
+// validateSequencePerRecordFirst runs every per-record check before any
+// pairwise sequence check (the PerRecordFirst policy)
+func (dv *DataValidator) validateSequencePerRecordFirst(records []models.DistanceRecord) error {
 	// Validate each record individually first
 	for i, record := range records {
 		if err := dv.ValidateRecord(record); err != nil {
@@ -195,77 +602,449 @@ func (dv *DataValidator) ValidateSequence(records []models.DistanceRecord) error
 			return err
 		}
 	}
-	
+
 	// Validate sequence constraints
+	intervalViolations := 0
+	var jitterBudgetUsed time.Duration
+	var identicalTimestampRun int
 	for i := 1; i < len(records); i++ {
 		current := records[i]
 		previous := records[i-1]
-		
+
 		// Validate timing constraints
-		if err := dv.validateTimingConstraints(previous, current, i); err != nil {
-			return err
+		if dv.RequireTimestamp {
+			if err := dv.validateTimingConstraints(previous, current, i, &intervalViolations, &jitterBudgetUsed, &identicalTimestampRun, i == len(records)-1); err != nil {
+				return err
+			}
 		}
-		
+
 		// Validate mileage progression
-		if err := dv.validateMileageProgression(previous, current, i); err != nil {
+		if dv.RequireDistance {
+			if err := dv.validateMileageProgression(previous, current, i); err != nil {
+				return err
+			}
+		}
+
+		if dv.RequireTimestamp && dv.RequireDistance {
+			if err := dv.validateProgressTogether(previous, current, i); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSequenceInterleaved validates each record together with its
+// pairwise checks against the predecessor in a single forward pass, so the
+// earliest positional problem in the sequence is reported first (the
+// Interleaved policy)
+func (dv *DataValidator) validateSequenceInterleaved(records []models.DistanceRecord) error {
+	if err := dv.ValidateRecord(records[0]); err != nil {
+		if ve, ok := err.(*ValidationError); ok {
+			ve.RecordIndex = 0
+		}
+		return err
+	}
+
+	intervalViolations := 0
+	var jitterBudgetUsed time.Duration
+	var identicalTimestampRun int
+	for i := 1; i < len(records); i++ {
+		current := records[i]
+		previous := records[i-1]
+
+		if err := dv.ValidateRecord(current); err != nil {
+			if ve, ok := err.(*ValidationError); ok {
+				ve.RecordIndex = i
+			}
 			return err
 		}
+
+		if dv.RequireTimestamp {
+			if err := dv.validateTimingConstraints(previous, current, i, &intervalViolations, &jitterBudgetUsed, &identicalTimestampRun, i == len(records)-1); err != nil {
+				return err
+			}
+		}
+
+		if dv.RequireDistance {
+			if err := dv.validateMileageProgression(previous, current, i); err != nil {
+				return err
+			}
+		}
+
+		if dv.RequireTimestamp && dv.RequireDistance {
+			if err := dv.validateProgressTogether(previous, current, i); err != nil {
+				return err
+			}
+		}
 	}
-	
+
 	return nil
 }
 
-// validateTimingConstraints checks timing constraints between consecutive records
-func (dv *DataValidator) validateTimingConstraints(previous, current models.DistanceRecord, currentIndex int) error {
-	timeDiff := current.Timestamp.Sub(previous.Timestamp)
-	
-	// Check for non-decreasing timestamps
-	if timeDiff < 0 {
-		return TimingError(currentIndex, 
-			fmt.Sprintf("timestamp must be non-decreasing, got %s before %s", 
-				current.Timestamp.Format("15:04:05.000"), 
-				previous.Timestamp.Format("15:04:05.000")),
-			current.Timestamp)
+// ValidationReport summarizes every validation problem found across a
+// sequence of DistanceRecord entries, rather than stopping at the first one
+// the way ValidateSequence does. It's intended for data pipelines and
+// dashboards, where the distribution of problems across a batch matters
+// more than any single error.
+type ValidationReport struct {
+	TotalRecords int
+	Passed       bool
+	CountsByType map[ValidationErrorType]int
+	FirstByType  map[ValidationErrorType]*ValidationError
+}
+
+// ValidateSequenceReport runs the same per-record and pairwise checks as
+// ValidateSequence, but instead of returning on the first violation it
+// evaluates every check and accumulates the results into a ValidationReport:
+// a count per ValidationErrorType plus the first error observed of each
+// type. Passed is true only if no violations were found anywhere in the
+// sequence.
+func (dv *DataValidator) ValidateSequenceReport(records []models.DistanceRecord) ValidationReport {
+	report := ValidationReport{
+		TotalRecords: len(records),
+		CountsByType: make(map[ValidationErrorType]int),
+		FirstByType:  make(map[ValidationErrorType]*ValidationError),
 	}
-	
-	// Check for identical timestamps if not allowed
-	if timeDiff == 0 && !dv.AllowIdenticalTimestamps {
-		return TimingError(currentIndex, 
-			fmt.Sprintf("identical timestamps not allowed: %s", 
-				current.Timestamp.Format("15:04:05.000")),
-			current.Timestamp)
+
+	record := func(err error) {
+		if err == nil {
+			return
+		}
+		ve, ok := dv.withDisplayBase(err).(*ValidationError)
+		if !ok {
+			return
+		}
+		report.CountsByType[ve.Type]++
+		if _, exists := report.FirstByType[ve.Type]; !exists {
+			report.FirstByType[ve.Type] = ve
+		}
 	}
-	
-	// Check maximum interval constraint
-	if timeDiff > dv.MaxInterval {
-		return TimingError(currentIndex, 
-			fmt.Sprintf("time interval exceeds maximum allowed (%v), got %v", 
-				dv.MaxInterval, timeDiff),
-			timeDiff)
+
+	if len(records) == 0 {
+		record(SequenceError("sequence cannot be empty", 0))
+		report.Passed = len(report.CountsByType) == 0
+		return report
 	}
-	
+
+	sequence := dv.SortSequence(records)
+
+	for i, rec := range sequence {
+		if err := dv.ValidateRecord(rec); err != nil {
+			if ve, ok := err.(*ValidationError); ok {
+				ve.RecordIndex = i
+			}
+			record(err)
+		}
+	}
+
+	if len(sequence) > 1 {
+		if dv.RejectZeroTotalDistance && sequence[0].Distance.Equal(sequence[len(sequence)-1].Distance) {
+			record(ConstraintError(len(sequence)-1, "distance",
+				"trip shows no net movement between first and last record", sequence[len(sequence)-1].Distance))
+		}
+
+		intervalViolations := 0
+		var jitterBudgetUsed time.Duration
+		var identicalTimestampRun int
+		for i := 1; i < len(sequence); i++ {
+			current := sequence[i]
+			previous := sequence[i-1]
+
+			if dv.RequireTimestamp {
+				record(dv.validateTimingConstraints(previous, current, i, &intervalViolations, &jitterBudgetUsed, &identicalTimestampRun, i == len(sequence)-1))
+			}
+			if dv.RequireDistance {
+				record(dv.validateMileageProgression(previous, current, i))
+			}
+			if dv.RequireTimestamp && dv.RequireDistance {
+				record(dv.validateProgressTogether(previous, current, i))
+			}
+		}
+
+		if !dv.SpeedOutlierFactor.IsZero() && dv.RequireTimestamp && dv.RequireDistance {
+			record(dv.validateSpeedOutliers(sequence))
+		}
+	}
+
+	report.Passed = len(report.CountsByType) == 0
+	return report
+}
+
+// LongestValidRun scans records for the longest contiguous subsequence that
+// passes ValidateRecord and, for every consecutive pair within it, the same
+// pairwise timing/mileage checks ValidateSequence applies (subject to
+// RequireTimestamp/RequireDistance). It returns the bounds of that
+// subsequence as a half-open range: records[start:end] is the longest valid
+// run. If records is empty, or no record individually validates, start and
+// end are both 0.
+//
+// This lets a caller salvage a fare calculation from an otherwise-rejected
+// sequence by computing over just the returned span, rather than discarding
+// the whole sequence on the first violation.
+func (dv *DataValidator) LongestValidRun(records []models.DistanceRecord) (start, end int) {
+	bestStart, bestLen := 0, 0
+	curStart := 0
+	intervalViolations := 0
+	var jitterBudgetUsed time.Duration
+	var identicalTimestampRun int
+
+	for i := 0; i < len(records); i++ {
+		if err := dv.ValidateRecord(records[i]); err != nil {
+			curStart = i + 1
+			intervalViolations = 0
+			jitterBudgetUsed = 0
+			identicalTimestampRun = 0
+			continue
+		}
+
+		if i > curStart {
+			broken := false
+			if dv.RequireTimestamp {
+				if err := dv.validateTimingConstraints(records[i-1], records[i], i, &intervalViolations, &jitterBudgetUsed, &identicalTimestampRun, false); err != nil {
+					broken = true
+				}
+			}
+			if !broken && dv.RequireDistance {
+				if err := dv.validateMileageProgression(records[i-1], records[i], i); err != nil {
+					broken = true
+				}
+			}
+			if !broken && dv.RequireTimestamp && dv.RequireDistance {
+				if err := dv.validateProgressTogether(records[i-1], records[i], i); err != nil {
+					broken = true
+				}
+			}
+			if broken {
+				curStart = i
+				intervalViolations = 0
+				jitterBudgetUsed = 0
+				identicalTimestampRun = 0
+			}
+		}
+
+		if runLen := i - curStart + 1; runLen > bestLen {
+			bestLen = runLen
+			bestStart = curStart
+		}
+	}
+
+	return bestStart, bestStart + bestLen
+}
+
+// validateTimingConstraints checks timing constraints between consecutive
+// records. intervalViolations tracks the running count of tolerated
+// MaxInterval violations across the whole sequence; jitterBudgetUsed tracks
+// the running sum of excess-over-MaxInterval time when IntervalJitterBudget
+// is set; identicalTimestampRun tracks the running count of consecutive
+// identical timestamps when MaxIdenticalTimestampRun is set. All three are
+// shared across calls for a single ValidateSequence invocation. isLastPair
+// marks the final consecutive pair in the sequence, so RelaxLastInterval can
+// exempt it from the MaxInterval check.
+func (dv *DataValidator) validateTimingConstraints(previous, current models.DistanceRecord, currentIndex int, intervalViolations *int, jitterBudgetUsed *time.Duration, identicalTimestampRun *int, isLastPair bool) error {
+	timeDiff := current.Timestamp.Sub(previous.Timestamp)
+
+	if dv.RequireStrictlyIncreasingTime {
+		// Equal or decreasing timestamps are both rejected under one
+		// consistent message; AllowIdenticalTimestamps does not apply here.
+		if timeDiff <= 0 {
+			return TimingError(currentIndex,
+				fmt.Sprintf("timestamp must be strictly increasing, got %s after %s",
+					current.Timestamp.Format("15:04:05.000"),
+					previous.Timestamp.Format("15:04:05.000")),
+				current.Timestamp)
+		}
+	} else {
+		// Check for non-decreasing timestamps
+		if timeDiff < 0 {
+			return TimingError(currentIndex,
+				fmt.Sprintf("timestamp must be non-decreasing, got %s before %s",
+					current.Timestamp.Format("15:04:05.000"),
+					previous.Timestamp.Format("15:04:05.000")),
+				current.Timestamp)
+		}
+
+		// Check for identical timestamps if not allowed, tolerating up to
+		// MaxIdenticalTimestampRun consecutive occurrences when set.
+		if timeDiff == 0 {
+			if !dv.AllowIdenticalTimestamps {
+				*identicalTimestampRun++
+				if *identicalTimestampRun > dv.MaxIdenticalTimestampRun {
+					return TimingError(currentIndex,
+						fmt.Sprintf("identical timestamps not allowed: %s",
+							current.Timestamp.Format("15:04:05.000")),
+						current.Timestamp)
+				}
+			}
+		} else {
+			*identicalTimestampRun = 0
+		}
+	}
+
+	// Check maximum interval constraint, tolerating either up to
+	// MaxIntervalViolations isolated violations or, when IntervalJitterBudget
+	// is set, a cumulative excess-over-MaxInterval budget across the whole
+	// sequence. RelaxLastInterval exempts the final pair entirely, ahead of
+	// either tolerance accounting.
+	if timeDiff > dv.MaxInterval && !(dv.RelaxLastInterval && isLastPair) {
+		if dv.IntervalJitterBudget > 0 {
+			*jitterBudgetUsed += timeDiff - dv.MaxInterval
+			if *jitterBudgetUsed > dv.IntervalJitterBudget {
+				return TimingError(currentIndex,
+					fmt.Sprintf("cumulative interval overage exceeds jitter budget (%v), got %v after a gap of %v (max %v)",
+						dv.IntervalJitterBudget, *jitterBudgetUsed, timeDiff, dv.MaxInterval),
+					timeDiff)
+			}
+		} else {
+			*intervalViolations++
+			if *intervalViolations > dv.MaxIntervalViolations {
+				return TimingError(currentIndex,
+					fmt.Sprintf("time interval exceeds maximum allowed (%v), got %v",
+						dv.MaxInterval, timeDiff),
+					timeDiff)
+			}
+		}
+	}
+
 	return nil
 }
 
 // validateMileageProgression checks mileage progression between consecutive records
 func (dv *DataValidator) validateMileageProgression(previous, current models.DistanceRecord, currentIndex int) error {
 	mileageDiff := current.Distance.Sub(previous.Distance)
-	
+
 	// Check for non-decreasing mileage
 	if mileageDiff.IsNegative() {
-		return MileageError(currentIndex, 
-			fmt.Sprintf("mileage must be non-decreasing, got %s before %s", 
+		if dv.isOdometerRollover(previous, current) {
+			return nil
+		}
+		return MileageError(currentIndex,
+			fmt.Sprintf("mileage must be non-decreasing, got %s before %s",
 				current.Distance.String(), previous.Distance.String()),
 			current.Distance)
 	}
-	
+
 	// Check for identical mileage if not allowed
 	if mileageDiff.IsZero() && !dv.AllowIdenticalMileage {
-		return MileageError(currentIndex, 
-			fmt.Sprintf("identical mileage readings not allowed: %s", 
+		return MileageError(currentIndex,
+			fmt.Sprintf("identical mileage readings not allowed: %s",
 				current.Distance.String()),
 			current.Distance)
 	}
-	
+
+	return nil
+}
+
+// rolloverBandFraction is the fraction of OdometerMax, at both ends of the
+// odometer's range, within which a mileage decrease is treated as a
+// plausible rollover rather than a genuine reading error.
+var rolloverBandFraction = decimal.NewFromFloat(0.1)
+
+// isOdometerRollover reports whether previous -> current looks like an
+// odometer rollover: OdometerMax is set, previous is within the top
+// rolloverBandFraction of the odometer's range, and current is within the
+// bottom rolloverBandFraction. The caller is expected to already know
+// current.Distance < previous.Distance.
+func (dv *DataValidator) isOdometerRollover(previous, current models.DistanceRecord) bool {
+	if dv.OdometerMax.IsZero() {
+		return false
+	}
+	band := dv.OdometerMax.Mul(rolloverBandFraction)
+	nearMax := previous.Distance.GreaterThanOrEqual(dv.OdometerMax.Sub(band))
+	nearZero := current.Distance.LessThanOrEqual(band)
+	return nearMax && nearZero
+}
+
+// SegmentDistance returns the distance travelled between two consecutive
+// records: normally current.Distance - previous.Distance, or, when
+// isOdometerRollover recognizes a plausible odometer rollover, the
+// reinterpreted (OdometerMax - previous.Distance) + current.Distance total
+// that the pairwise mileage decrease would otherwise hide.
+func (dv *DataValidator) SegmentDistance(previous, current models.DistanceRecord) decimal.Decimal {
+	if dv.isOdometerRollover(previous, current) {
+		return dv.OdometerMax.Sub(previous.Distance).Add(current.Distance)
+	}
+	return current.Distance.Sub(previous.Distance)
+}
+
+// validateProgressTogether checks, when RequireProgressTogether is enabled,
+// that a consecutive pair's time advance beyond ProgressStallThreshold is
+// accompanied by some mileage change, flagging a large time advance with
+// exactly zero mileage change as a suspicious ConstraintError (a stuck
+// sensor) rather than a genuine stationary stop.
+func (dv *DataValidator) validateProgressTogether(previous, current models.DistanceRecord, currentIndex int) error {
+	if !dv.RequireProgressTogether {
+		return nil
+	}
+
+	timeDiff := current.Timestamp.Sub(previous.Timestamp)
+	mileageDiff := current.Distance.Sub(previous.Distance)
+
+	if timeDiff > dv.ProgressStallThreshold && mileageDiff.IsZero() {
+		return ConstraintError(currentIndex, "distance",
+			fmt.Sprintf("no mileage change over a time advance of %v (threshold %v), possible stuck sensor",
+				timeDiff, dv.ProgressStallThreshold),
+			current.Distance)
+	}
+
+	return nil
+}
+
+// validateSpeedOutliers implements the SpeedOutlierFactor check as a two-pass
+// scan: the first pass computes each segment's implied speed and their
+// median, the second flags any segment whose speed deviates from the median
+// by more than SpeedOutlierFactor.
+func (dv *DataValidator) validateSpeedOutliers(records []models.DistanceRecord) error {
+	type segment struct {
+		index int
+		speed decimal.Decimal
+	}
+
+	segments := make([]segment, 0, len(records)-1)
+	for i := 1; i < len(records); i++ {
+		timeDiff := records[i].Timestamp.Sub(records[i-1].Timestamp)
+		if timeDiff <= 0 {
+			continue
+		}
+		distDiff := records[i].Distance.Sub(records[i-1].Distance)
+		speed := distDiff.Div(decimal.NewFromFloat(timeDiff.Seconds()))
+		segments = append(segments, segment{index: i, speed: speed})
+	}
+
+	if len(segments) == 0 {
+		return nil
+	}
+
+	speeds := make([]decimal.Decimal, len(segments))
+	for i, seg := range segments {
+		speeds[i] = seg.speed
+	}
+	sort.Slice(speeds, func(i, j int) bool {
+		return speeds[i].LessThan(speeds[j])
+	})
+
+	var median decimal.Decimal
+	mid := len(speeds) / 2
+	if len(speeds)%2 == 0 {
+		median = speeds[mid-1].Add(speeds[mid]).Div(decimal.NewFromInt(2))
+	} else {
+		median = speeds[mid]
+	}
+
+	if median.IsZero() {
+		return nil
+	}
+
+	for _, seg := range segments {
+		deviation := seg.speed.Sub(median).Abs().Div(median)
+		if deviation.GreaterThan(dv.SpeedOutlierFactor) {
+			return ConstraintError(seg.index, "speed",
+				fmt.Sprintf("segment speed %s deviates from median %s by more than %s%%",
+					seg.speed.String(), median.String(), dv.SpeedOutlierFactor.Mul(decimal.NewFromInt(100)).String()),
+				seg.speed)
+		}
+	}
+
 	return nil
 }
\ No newline at end of file
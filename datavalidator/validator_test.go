@@ -1,6 +1,7 @@
 package datavalidator
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -143,6 +144,14 @@ func TestNewValidator(t *testing.T) {
 	if !dv.AllowIdenticalMileage {
 		t.Error("Expected identical mileage to be allowed by default")
 	}
+
+	if !dv.RequireTimestamp {
+		t.Error("Expected RequireTimestamp to be true by default")
+	}
+
+	if !dv.RequireDistance {
+		t.Error("Expected RequireDistance to be true by default")
+	}
 }
 
 func TestNewValidatorWithOptions(t *testing.T) {
@@ -167,6 +176,56 @@ func TestNewValidatorWithOptions(t *testing.T) {
 	}
 }
 
+func TestNewValidatorFromEnv(t *testing.T) {
+	t.Run("overrides from valid environment variables", func(t *testing.T) {
+		t.Setenv("TAXIFARE_MAX_INTERVAL", "2m")
+		t.Setenv("TAXIFARE_MAX_SPEED", "0.25")
+		t.Setenv("TAXIFARE_MAX_DISTANCE", "99999")
+
+		dv := NewValidatorFromEnv().(*DataValidator)
+
+		if dv.MaxInterval != 2*time.Minute {
+			t.Errorf("Expected MaxInterval 2m, got %v", dv.MaxInterval)
+		}
+		if !dv.SpeedOutlierFactor.Equal(decimal.NewFromFloat(0.25)) {
+			t.Errorf("Expected SpeedOutlierFactor 0.25, got %s", dv.SpeedOutlierFactor.String())
+		}
+		if !dv.MaxDistance.Equal(decimal.NewFromInt(99999)) {
+			t.Errorf("Expected MaxDistance 99999, got %s", dv.MaxDistance.String())
+		}
+	})
+
+	t.Run("falls back to defaults when unset", func(t *testing.T) {
+		dv := NewValidatorFromEnv().(*DataValidator)
+		defaults := NewValidator().(*DataValidator)
+
+		if dv.MaxInterval != defaults.MaxInterval {
+			t.Errorf("Expected default MaxInterval %v, got %v", defaults.MaxInterval, dv.MaxInterval)
+		}
+		if !dv.SpeedOutlierFactor.Equal(defaults.SpeedOutlierFactor) {
+			t.Errorf("Expected default SpeedOutlierFactor %s, got %s", defaults.SpeedOutlierFactor.String(), dv.SpeedOutlierFactor.String())
+		}
+		if !dv.MaxDistance.Equal(defaults.MaxDistance) {
+			t.Errorf("Expected default MaxDistance %s, got %s", defaults.MaxDistance.String(), dv.MaxDistance.String())
+		}
+	})
+
+	t.Run("falls back to defaults on parse failure", func(t *testing.T) {
+		t.Setenv("TAXIFARE_MAX_INTERVAL", "not-a-duration")
+		t.Setenv("TAXIFARE_MAX_SPEED", "not-a-decimal")
+
+		dv := NewValidatorFromEnv().(*DataValidator)
+		defaults := NewValidator().(*DataValidator)
+
+		if dv.MaxInterval != defaults.MaxInterval {
+			t.Errorf("Expected default MaxInterval %v on parse failure, got %v", defaults.MaxInterval, dv.MaxInterval)
+		}
+		if !dv.SpeedOutlierFactor.Equal(defaults.SpeedOutlierFactor) {
+			t.Errorf("Expected default SpeedOutlierFactor on parse failure, got %s", dv.SpeedOutlierFactor.String())
+		}
+	})
+}
+
 func TestDataValidator_ValidateRecord(t *testing.T) {
 	validator := NewValidator().(*DataValidator)
 	
@@ -387,6 +446,124 @@ func TestDataValidator_ValidateSequence(t *testing.T) {
 	})
 }
 
+// fixedClock implements Clock and always returns the same instant
+type fixedClock struct {
+	now time.Time
+}
+
+func (fc fixedClock) Now() time.Time {
+	return fc.now
+}
+
+func TestDataValidator_RejectFutureTimestamps(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("future dated record rejected", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RejectFutureTimestamps = true
+		validator.Clock = fixedClock{now: now}
+
+		record := models.DistanceRecord{
+			Timestamp: now.Add(1 * time.Hour),
+			Distance:  decimal.NewFromFloat(12345678.9),
+		}
+
+		err := validator.ValidateRecord(record)
+		if err == nil {
+			t.Fatal("Expected error for future-dated timestamp")
+		}
+
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T", err)
+		}
+		if ve.Type != ValidationErrorTypeConstraint {
+			t.Errorf("Expected constraint error, got %v", ve.Type)
+		}
+	})
+
+	t.Run("undated timestamp is a no-op", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RejectFutureTimestamps = true
+		validator.Clock = fixedClock{now: now}
+
+		record := models.DistanceRecord{
+			Timestamp: time.Date(0, 1, 1, 23, 59, 59, 0, time.UTC), // no date component
+			Distance:  decimal.NewFromFloat(12345678.9),
+		}
+
+		if err := validator.ValidateRecord(record); err != nil {
+			t.Errorf("Expected no error for undated timestamp, got %v", err)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.Clock = fixedClock{now: now}
+
+		record := models.DistanceRecord{
+			Timestamp: now.Add(1 * time.Hour),
+			Distance:  decimal.NewFromFloat(12345678.9),
+		}
+
+		if err := validator.ValidateRecord(record); err != nil {
+			t.Errorf("Expected no error when RejectFutureTimestamps is disabled, got %v", err)
+		}
+	})
+}
+
+func TestDataValidator_MaxDistance(t *testing.T) {
+	t.Run("absurdly large reading rejected when bound is set", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxDistance = decimal.NewFromInt(99999999)
+
+		record := models.DistanceRecord{
+			Timestamp: time.Now(),
+			Distance:  decimal.RequireFromString("99999999999999.9"),
+		}
+
+		err := validator.ValidateRecord(record)
+		if err == nil {
+			t.Fatal("Expected error for distance exceeding MaxDistance")
+		}
+
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T", err)
+		}
+		if ve.Type != ValidationErrorTypeConstraint {
+			t.Errorf("Expected constraint error, got %v", ve.Type)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		record := models.DistanceRecord{
+			Timestamp: time.Now(),
+			Distance:  decimal.RequireFromString("99999999999999.9"),
+		}
+
+		if err := validator.ValidateRecord(record); err != nil {
+			t.Errorf("Expected no error when MaxDistance is unset, got %v", err)
+		}
+	})
+
+	t.Run("reading within bound is accepted", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxDistance = decimal.NewFromInt(99999999)
+
+		record := models.DistanceRecord{
+			Timestamp: time.Now(),
+			Distance:  decimal.NewFromFloat(12345678.9),
+		}
+
+		if err := validator.ValidateRecord(record); err != nil {
+			t.Errorf("Expected no error for distance within MaxDistance, got %v", err)
+		}
+	})
+}
+
 func TestDataValidator_ValidateSequenceWithStrictOptions(t *testing.T) {
 	validator := NewValidatorWithOptions(5*time.Minute, false, false).(*DataValidator)
 	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
@@ -427,12 +604,12 @@ func TestDataValidator_ValidateSequenceWithStrictOptions(t *testing.T) {
 				Distance:  decimal.NewFromFloat(12345678.9), // Same distance
 			},
 		}
-		
+
 		err := validator.ValidateSequence(records)
 		if err == nil {
 			t.Error("Expected error for identical mileage when not allowed")
 		}
-		
+
 		ve, ok := err.(*ValidationError)
 		if !ok {
 			t.Errorf("Expected ValidationError, got %T", err)
@@ -440,4 +617,857 @@ func TestDataValidator_ValidateSequenceWithStrictOptions(t *testing.T) {
 			t.Errorf("Expected mileage error, got %v", ve.Type)
 		}
 	})
+}
+
+func TestDataValidator_CheckOrder(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Record 1 has a pairwise timing problem relative to record 0 (identical
+	// timestamps, not allowed), while record 2 has a per-record problem
+	// (negative distance) of its own. PerRecordFirst scans every record
+	// before any pairwise check, so it reaches record 2 first; Interleaved
+	// checks record 1's pair before ever looking at record 2.
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1500)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(-1)},
+	}
+
+	t.Run("PerRecordFirst reports the later per-record error", func(t *testing.T) {
+		validator := NewValidatorWithOptions(5*time.Minute, false, true).(*DataValidator)
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeConstraint || ve.RecordIndex != 2 {
+			t.Errorf("Expected constraint error at record 2, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("Interleaved reports the earlier pairwise error", func(t *testing.T) {
+		validator := NewValidatorWithOptions(5*time.Minute, false, true).(*DataValidator)
+		validator.CheckOrder = Interleaved
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 1 {
+			t.Errorf("Expected timing error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+}
+
+func TestDataValidator_LongestValidRun(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	validator := NewValidator().(*DataValidator)
+
+	t.Run("sequence broken in the middle returns the longer side", func(t *testing.T) {
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1100)},
+			// Break: mileage goes backwards here.
+			{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(900)},
+			{Timestamp: baseTime.Add(3 * time.Minute), Distance: decimal.NewFromInt(1200)},
+			{Timestamp: baseTime.Add(4 * time.Minute), Distance: decimal.NewFromInt(1300)},
+			{Timestamp: baseTime.Add(5 * time.Minute), Distance: decimal.NewFromInt(1400)},
+		}
+
+		start, end := validator.LongestValidRun(records)
+		if start != 2 || end != 6 {
+			t.Errorf("Expected longest run [2:6), got [%d:%d)", start, end)
+		}
+
+		if err := validator.ValidateSequence(records[start:end]); err != nil {
+			t.Errorf("Returned run should pass ValidateSequence, got error: %v", err)
+		}
+	})
+
+	t.Run("fully valid sequence returns the whole range", func(t *testing.T) {
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1100)},
+			{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(1200)},
+		}
+
+		start, end := validator.LongestValidRun(records)
+		if start != 0 || end != 3 {
+			t.Errorf("Expected full range [0:3), got [%d:%d)", start, end)
+		}
+	})
+
+	t.Run("empty sequence returns zero bounds", func(t *testing.T) {
+		start, end := validator.LongestValidRun(nil)
+		if start != 0 || end != 0 {
+			t.Errorf("Expected [0:0), got [%d:%d)", start, end)
+		}
+	})
+}
+
+func TestDataValidator_ValidateSequenceReport(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	validator := NewValidator().(*DataValidator)
+
+	t.Run("sequence with timing and mileage violations", func(t *testing.T) {
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1100)},
+			// Both timestamp and mileage go backwards here.
+			{Timestamp: baseTime.Add(30 * time.Second), Distance: decimal.NewFromInt(1050)},
+			{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(1200)},
+			// Timestamp goes backwards again, but mileage keeps increasing.
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1300)},
+		}
+
+		report := validator.ValidateSequenceReport(records)
+
+		if report.TotalRecords != 5 {
+			t.Errorf("TotalRecords = %d, want 5", report.TotalRecords)
+		}
+		if report.Passed {
+			t.Error("Expected Passed = false for a sequence with violations")
+		}
+		if got := report.CountsByType[ValidationErrorTypeTiming]; got != 2 {
+			t.Errorf("CountsByType[ValidationErrorTypeTiming] = %d, want 2", got)
+		}
+		if got := report.CountsByType[ValidationErrorTypeMileage]; got != 1 {
+			t.Errorf("CountsByType[ValidationErrorTypeMileage] = %d, want 1", got)
+		}
+		if first := report.FirstByType[ValidationErrorTypeTiming]; first == nil || first.RecordIndex != 2 {
+			t.Errorf("Expected first timing error at record index 2, got %+v", first)
+		}
+		if first := report.FirstByType[ValidationErrorTypeMileage]; first == nil || first.RecordIndex != 2 {
+			t.Errorf("Expected first mileage error at record index 2, got %+v", first)
+		}
+	})
+
+	t.Run("fully valid sequence passes with no counted violations", func(t *testing.T) {
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1100)},
+			{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(1200)},
+		}
+
+		report := validator.ValidateSequenceReport(records)
+
+		if !report.Passed {
+			t.Errorf("Expected Passed = true, got report: %+v", report)
+		}
+		if len(report.CountsByType) != 0 {
+			t.Errorf("Expected no violations, got: %+v", report.CountsByType)
+		}
+	})
+
+	t.Run("empty sequence fails with a sequence-level error", func(t *testing.T) {
+		report := validator.ValidateSequenceReport(nil)
+
+		if report.Passed {
+			t.Error("Expected Passed = false for an empty sequence")
+		}
+		if got := report.CountsByType[ValidationErrorTypeSequence]; got != 1 {
+			t.Errorf("CountsByType[ValidationErrorTypeSequence] = %d, want 1", got)
+		}
+	})
+}
+
+func TestDataValidator_RequireStrictlyIncreasingTime(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("equal timestamps rejected", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RequireStrictlyIncreasingTime = true
+		validator.AllowIdenticalTimestamps = true // ignored when strict mode is on
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1100)},
+		}
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 1 {
+			t.Errorf("Expected timing error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("decreasing timestamps rejected", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RequireStrictlyIncreasingTime = true
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1000)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1100)},
+		}
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 1 {
+			t.Errorf("Expected timing error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("strictly increasing timestamps pass", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RequireStrictlyIncreasingTime = true
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1100)},
+		}
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("default is false, preserving independent checks", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		if validator.RequireStrictlyIncreasingTime {
+			t.Error("Expected RequireStrictlyIncreasingTime to default to false")
+		}
+	})
+
+	// Contrast the two flags directly on the same equal-timestamp sequence:
+	// AllowIdenticalTimestamps governs acceptance when not strict,
+	// RequireStrictlyIncreasingTime overrides it unconditionally when strict.
+	equalTimestampRecords := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1100)},
+	}
+
+	t.Run("not strict, AllowIdenticalTimestamps true accepts equal timestamps", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.AllowIdenticalTimestamps = true
+
+		if err := validator.ValidateSequence(equalTimestampRecords); err != nil {
+			t.Errorf("ValidateSequence() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("not strict, AllowIdenticalTimestamps false rejects equal timestamps", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.AllowIdenticalTimestamps = false
+
+		err := validator.ValidateSequence(equalTimestampRecords)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 1 {
+			t.Errorf("Expected timing error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("strict rejects equal timestamps regardless of AllowIdenticalTimestamps", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RequireStrictlyIncreasingTime = true
+		validator.AllowIdenticalTimestamps = true
+
+		err := validator.ValidateSequence(equalTimestampRecords)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 1 {
+			t.Errorf("Expected timing error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+}
+
+func TestDataValidator_RequireTimestamp(t *testing.T) {
+	validator := NewValidator().(*DataValidator)
+	validator.RequireTimestamp = false
+
+	// Zero/identical timestamps would normally be rejected, but timestamps
+	// aren't required here; mileage still progresses normally.
+	records := []models.DistanceRecord{
+		{Timestamp: time.Time{}, Distance: decimal.NewFromInt(1000)},
+		{Timestamp: time.Time{}, Distance: decimal.NewFromInt(1500)},
+		{Timestamp: time.Time{}, Distance: decimal.NewFromInt(2000)},
+	}
+
+	if err := validator.ValidateSequence(records); err != nil {
+		t.Errorf("ValidateSequence() unexpected error with timestamps not required: %v", err)
+	}
+
+	if err := validator.ValidateRecord(records[0]); err != nil {
+		t.Errorf("ValidateRecord() unexpected error for zero timestamp: %v", err)
+	}
+}
+
+func TestDataValidator_RequireDistance(t *testing.T) {
+	validator := NewValidator().(*DataValidator)
+	validator.RequireDistance = false
+
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Decreasing distance would normally be rejected, but distance
+	// progression isn't required here.
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1500)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(500)},
+	}
+
+	if err := validator.ValidateSequence(records); err != nil {
+		t.Errorf("ValidateSequence() unexpected error with distance progression not required: %v", err)
+	}
+
+	// Negative distance is still always rejected, regardless of RequireDistance
+	negative := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(-1)},
+	}
+	if err := validator.ValidateRecord(negative[0]); err == nil {
+		t.Error("ValidateRecord() expected error for negative distance even when RequireDistance is false")
+	}
+}
+
+func TestDataValidator_MaxIntervalViolations(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Two isolated gaps slightly over the 1-minute MaxInterval, surrounded by
+	// records within the limit.
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+		{Timestamp: baseTime.Add(90 * time.Second), Distance: decimal.NewFromInt(1500)}, // 1st violation
+		{Timestamp: baseTime.Add(110 * time.Second), Distance: decimal.NewFromInt(1600)},
+		{Timestamp: baseTime.Add(200 * time.Second), Distance: decimal.NewFromInt(2000)}, // 2nd violation
+		{Timestamp: baseTime.Add(230 * time.Second), Distance: decimal.NewFromInt(2100)},
+	}
+
+	t.Run("tolerated within the allowance", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxInterval = time.Minute
+		validator.MaxIntervalViolations = 2
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error with 2 violations and an allowance of 2: %v", err)
+		}
+	})
+
+	t.Run("fails on the violation past the allowance", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxInterval = time.Minute
+		validator.MaxIntervalViolations = 1
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 3 {
+			t.Errorf("Expected timing error at record 3, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("zero value fails on the first violation", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxInterval = time.Minute
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 1 {
+			t.Errorf("Expected timing error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+}
+
+func TestDataValidator_IntervalJitterBudget(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Three gaps each 10s over the 1-minute MaxInterval (30s cumulative
+	// excess), surrounded by records within the limit.
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+		{Timestamp: baseTime.Add(70 * time.Second), Distance: decimal.NewFromInt(1500)}, // +10s excess
+		{Timestamp: baseTime.Add(140 * time.Second), Distance: decimal.NewFromInt(1600)}, // +10s excess
+		{Timestamp: baseTime.Add(210 * time.Second), Distance: decimal.NewFromInt(2000)}, // +10s excess
+		{Timestamp: baseTime.Add(240 * time.Second), Distance: decimal.NewFromInt(2100)},
+	}
+
+	t.Run("cumulative excess within the budget passes", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxInterval = time.Minute
+		validator.IntervalJitterBudget = 30 * time.Second
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error with 30s cumulative excess and a 30s budget: %v", err)
+		}
+	})
+
+	t.Run("cumulative excess beyond the budget fails", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxInterval = time.Minute
+		validator.IntervalJitterBudget = 20 * time.Second
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 3 {
+			t.Errorf("Expected timing error at record 3, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("zero value disables the budget and falls back to MaxIntervalViolations", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxInterval = time.Minute
+		validator.MaxIntervalViolations = 10
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error with IntervalJitterBudget unset and a generous MaxIntervalViolations: %v", err)
+		}
+	})
+}
+
+func TestDataValidator_SpeedOutlierFactor(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Steady 10m/s segments, except one segment jumping 1000m in 10s (100m/s),
+	// a clear outlier against the ~10m/s median.
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+		{Timestamp: baseTime.Add(10 * time.Second), Distance: decimal.NewFromInt(100)},
+		{Timestamp: baseTime.Add(20 * time.Second), Distance: decimal.NewFromInt(200)},
+		{Timestamp: baseTime.Add(30 * time.Second), Distance: decimal.NewFromInt(300)},
+		{Timestamp: baseTime.Add(40 * time.Second), Distance: decimal.NewFromInt(1300)},
+		{Timestamp: baseTime.Add(50 * time.Second), Distance: decimal.NewFromInt(1400)},
+	}
+
+	t.Run("flags the outlier segment", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.SpeedOutlierFactor = decimal.NewFromFloat(0.5)
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeConstraint || ve.RecordIndex != 4 {
+			t.Errorf("Expected constraint error at record 4, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("Expected no error when SpeedOutlierFactor is unset, got %v", err)
+		}
+	})
+
+	t.Run("tolerant factor passes the same sequence", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.SpeedOutlierFactor = decimal.NewFromInt(100)
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("Expected no error with a very tolerant factor, got %v", err)
+		}
+	})
+}
+
+func TestDataValidator_DisplayIndexBase(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+		{Timestamp: baseTime.Add(10 * time.Second), Distance: decimal.NewFromInt(100)},
+		{Timestamp: baseTime.Add(20 * time.Second), Distance: decimal.NewFromInt(200)},
+		{Timestamp: baseTime.Add(30 * time.Second), Distance: decimal.NewFromInt(300)},
+		{Timestamp: baseTime.Add(40 * time.Second), Distance: decimal.NewFromInt(400)},
+		{Timestamp: baseTime.Add(50 * time.Second), Distance: decimal.NewFromInt(400)}, // identical mileage at index 5
+	}
+
+	t.Run("1-based shows record 6 for internal index 5", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.AllowIdenticalMileage = false
+		validator.DisplayIndexBase = 1
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.RecordIndex != 5 {
+			t.Fatalf("Expected internal RecordIndex 5, got %d", ve.RecordIndex)
+		}
+		if !strings.Contains(ve.Error(), "record 6") {
+			t.Errorf("Expected message to show \"record 6\", got %q", ve.Error())
+		}
+	})
+
+	t.Run("default base keeps 0-based messages", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.AllowIdenticalMileage = false
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if !strings.Contains(ve.Error(), "record 5") {
+			t.Errorf("Expected message to show \"record 5\", got %q", ve.Error())
+		}
+	})
+}
+
+func TestDataValidator_PreSortByTimestamp(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Records 1 and 2 are swapped out of timestamp order, as if delivered by
+	// async logging; mileage increases in timestamp order, not in the order
+	// given.
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+		{Timestamp: baseTime.Add(20 * time.Second), Distance: decimal.NewFromInt(200)},
+		{Timestamp: baseTime.Add(10 * time.Second), Distance: decimal.NewFromInt(100)},
+		{Timestamp: baseTime.Add(30 * time.Second), Distance: decimal.NewFromInt(300)},
+	}
+
+	t.Run("disabled by default: decreasing timestamp fails", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		err := validator.ValidateSequence(records)
+		if err == nil {
+			t.Fatal("Expected error for out-of-order timestamps, got nil")
+		}
+	})
+
+	t.Run("enabled: reordering is tolerated", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.PreSortByTimestamp = true
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("Expected no error with PreSortByTimestamp enabled, got %v", err)
+		}
+	})
+
+	t.Run("enabled: original slice is left unmodified", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.PreSortByTimestamp = true
+
+		original := append([]models.DistanceRecord(nil), records...)
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Fatalf("Expected no error with PreSortByTimestamp enabled, got %v", err)
+		}
+		for i := range records {
+			if !records[i].Timestamp.Equal(original[i].Timestamp) {
+				t.Errorf("records[%d].Timestamp changed from %v to %v; caller's slice should not be mutated",
+					i, original[i].Timestamp, records[i].Timestamp)
+			}
+		}
+	})
+}
+
+func TestDataValidator_RelaxLastInterval(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("disabled by default: large final gap fails", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(100)},
+			{Timestamp: baseTime.Add(time.Hour), Distance: decimal.NewFromInt(200)}, // large final gap
+		}
+
+		if err := validator.ValidateSequence(records); err == nil {
+			t.Fatal("Expected error for large final gap without RelaxLastInterval, got nil")
+		}
+	})
+
+	t.Run("enabled: large final gap is tolerated", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RelaxLastInterval = true
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(100)},
+			{Timestamp: baseTime.Add(time.Hour), Distance: decimal.NewFromInt(200)}, // large final gap
+		}
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("Expected no error with RelaxLastInterval enabled, got %v", err)
+		}
+	})
+
+	t.Run("enabled: equally large interior gap still fails", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RelaxLastInterval = true
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime.Add(time.Hour), Distance: decimal.NewFromInt(100)}, // large interior gap
+			{Timestamp: baseTime.Add(time.Hour + time.Minute), Distance: decimal.NewFromInt(200)},
+		}
+
+		if err := validator.ValidateSequence(records); err == nil {
+			t.Fatal("Expected error for large interior gap even with RelaxLastInterval, got nil")
+		}
+	})
+}
+
+func TestDataValidator_MaxIdenticalTimestampRun(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("run of 2 passes when the limit is 3", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.AllowIdenticalTimestamps = false
+		validator.MaxIdenticalTimestampRun = 3
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(10)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(20)},
+			{Timestamp: baseTime.Add(time.Second), Distance: decimal.NewFromInt(30)},
+		}
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error with a run of 2 and a limit of 3: %v", err)
+		}
+	})
+
+	t.Run("run of 4 fails when the limit is 3", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.AllowIdenticalTimestamps = false
+		validator.MaxIdenticalTimestampRun = 3
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(10)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(20)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(30)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(40)},
+		}
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 4 {
+			t.Errorf("Expected timing error at record 4, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("zero value fails immediately, matching historical behavior", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.AllowIdenticalTimestamps = false
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(10)},
+		}
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeTiming || ve.RecordIndex != 1 {
+			t.Errorf("Expected timing error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+}
+
+func TestDataValidator_RequireProgressTogether(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("disabled by default: a long stall passes", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(0)},
+		}
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error with the option disabled: %v", err)
+		}
+	})
+
+	t.Run("a long time advance with zero mileage change is flagged", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RequireProgressTogether = true
+		validator.ProgressStallThreshold = time.Minute
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime.Add(5 * time.Minute), Distance: decimal.NewFromInt(0)},
+		}
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeConstraint || ve.RecordIndex != 1 {
+			t.Errorf("Expected constraint error at record 1, got %s error at record %d", ve.Type, ve.RecordIndex)
+		}
+	})
+
+	t.Run("a long time advance with nonzero mileage change passes", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RequireProgressTogether = true
+		validator.ProgressStallThreshold = time.Minute
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime.Add(5 * time.Minute), Distance: decimal.NewFromInt(100)},
+		}
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error with nonzero mileage change: %v", err)
+		}
+	})
+}
+
+func TestDataValidator_OdometerMax(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("disabled by default: a mileage decrease fails", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(99500)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(500)},
+		}
+
+		if err := validator.ValidateSequence(records); err == nil {
+			t.Error("Expected ValidateSequence() to reject a mileage decrease with OdometerMax disabled")
+		}
+	})
+
+	t.Run("a rollover-shaped decrease passes and reports the true travel distance", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.OdometerMax = decimal.NewFromInt(100000)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(99500)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(500)},
+		}
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("ValidateSequence() unexpected error for a rollover-shaped decrease: %v", err)
+		}
+
+		got := validator.SegmentDistance(records[0], records[1])
+		want := decimal.NewFromInt(1000)
+		if !got.Equal(want) {
+			t.Errorf("SegmentDistance() = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("a decrease that isn't rollover-shaped still fails", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.OdometerMax = decimal.NewFromInt(100000)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(50000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(40000)},
+		}
+
+		if err := validator.ValidateSequence(records); err == nil {
+			t.Error("Expected ValidateSequence() to reject a large mid-range decrease that doesn't look like a rollover")
+		}
+	})
+}
+
+func TestDataValidator_MaxRecords(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(100)},
+		{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(200)},
+		{Timestamp: baseTime.Add(3 * time.Minute), Distance: decimal.NewFromInt(300)},
+	}
+
+	t.Run("disabled by default: long sequence passes", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("Expected no error with MaxRecords disabled, got %v", err)
+		}
+	})
+
+	t.Run("sequence within the cap passes", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxRecords = 4
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("Expected no error with a sequence at the cap, got %v", err)
+		}
+	})
+
+	t.Run("sequence exceeding the cap fails", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.MaxRecords = 3
+
+		err := validator.ValidateSequence(records)
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeSequence {
+			t.Errorf("Expected sequence error, got %s error", ve.Type)
+		}
+	})
+}
+
+func TestDataValidator_RejectZeroTotalDistance(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12345000)},
+		{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(12345000)},
+	}
+
+	t.Run("disabled by default: zero-movement trip passes", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+
+		if err := validator.ValidateSequence(records); err != nil {
+			t.Errorf("Expected no error with RejectZeroTotalDistance disabled, got %v", err)
+		}
+	})
+
+	t.Run("enabled: identical first/last distance is rejected", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RejectZeroTotalDistance = true
+
+		err := validator.ValidateSequence(records)
+		if err == nil {
+			t.Fatal("Expected error for zero-movement trip with RejectZeroTotalDistance enabled, got nil")
+		}
+		ve, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+		}
+		if ve.Type != ValidationErrorTypeConstraint {
+			t.Errorf("Expected ValidationErrorTypeConstraint, got %v", ve.Type)
+		}
+	})
+
+	t.Run("enabled: movement between first and last still passes", func(t *testing.T) {
+		validator := NewValidator().(*DataValidator)
+		validator.RejectZeroTotalDistance = true
+
+		moving := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12345100)},
+		}
+		if err := validator.ValidateSequence(moving); err != nil {
+			t.Errorf("Expected no error for a moving trip, got %v", err)
+		}
+	})
 }
\ No newline at end of file
@@ -4,6 +4,7 @@
 package errorhandler
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -11,8 +12,17 @@ import (
 
 	"golang-taxi-fare/datavalidator"
 	"golang-taxi-fare/inputparser"
+	"golang-taxi-fare/loggingsystem"
 )
 
+// ErrProcessingTimeout is returned by the root package's Application.Run
+// when a configured processing timeout elapses before processing finishes.
+// It is a distinct sentinel, rather than a plain fmt.Errorf, so
+// categorizeError can route it to ExitGeneralError before the generic
+// keyword-based fallback's "time" keyword would otherwise route it to
+// ExitTimingError.
+var ErrProcessingTimeout = errors.New("processing timed out")
+
 // ExitCode represents different application exit codes
 type ExitCode int
 
@@ -91,6 +101,16 @@ type ApplicationErrorHandler struct {
 	CaptureStackTrace bool
 	// ExitOnError determines whether to call os.Exit when handling errors
 	ExitOnError bool
+	// StackDepth is the maximum number of frames captureStackTrace records.
+	// Values below 1 are treated as 1.
+	StackDepth int
+
+	// Logger, when set, routes HandleErrorWithContext's diagnostic output
+	// through it as a structured ERROR log instead of the default raw
+	// fmt.Fprintf to stderr, so it shares one format with the rest of the
+	// application's logging. The zero value (nil) preserves the original
+	// raw-print behavior.
+	Logger loggingsystem.Logger
 }
 
 // NewErrorHandler creates a new ApplicationErrorHandler with default settings
@@ -98,6 +118,7 @@ func NewErrorHandler() ErrorHandler {
 	return &ApplicationErrorHandler{
 		CaptureStackTrace: true,
 		ExitOnError:       true,
+		StackDepth:        10,
 	}
 }
 
@@ -106,6 +127,7 @@ func NewErrorHandlerWithOptions(captureStackTrace, exitOnError bool) ErrorHandle
 	return &ApplicationErrorHandler{
 		CaptureStackTrace: captureStackTrace,
 		ExitOnError:       exitOnError,
+		StackDepth:        10,
 	}
 }
 
@@ -122,11 +144,18 @@ func (aeh *ApplicationErrorHandler) HandleErrorWithContext(err error, context ma
 	
 	exitCode := aeh.categorizeError(err)
 	errorContext := aeh.CreateErrorContext(err, context)
-	
-	// Print error information to stderr
-	fmt.Fprintf(os.Stderr, "Error: %s\n", errorContext.Message)
-	if errorContext.Details != "" {
-		fmt.Fprintf(os.Stderr, "Details: %s\n", errorContext.Details)
+
+	if aeh.Logger != nil {
+		aeh.Logger.Error(errorContext.Message,
+			"error_type", errorContext.ErrorType,
+			"details", errorContext.Details,
+		)
+	} else {
+		// Print error information to stderr
+		fmt.Fprintf(os.Stderr, "Error: %s\n", errorContext.Message)
+		if errorContext.Details != "" {
+			fmt.Fprintf(os.Stderr, "Details: %s\n", errorContext.Details)
+		}
 	}
 	
 	// Exit if configured to do so
@@ -184,7 +213,7 @@ func (aeh *ApplicationErrorHandler) CreateErrorContext(err error, context map[st
 	
 	// Capture stack trace if enabled
 	if aeh.CaptureStackTrace {
-		errorContext.StackTrace = captureStackTrace()
+		errorContext.StackTrace = captureStackTrace(aeh.StackDepth)
 	}
 	
 	return errorContext
@@ -192,6 +221,10 @@ func (aeh *ApplicationErrorHandler) CreateErrorContext(err error, context map[st
 
 // categorizeError determines the appropriate exit code for an error
 func (aeh *ApplicationErrorHandler) categorizeError(err error) ExitCode {
+	if errors.Is(err, ErrProcessingTimeout) {
+		return ExitGeneralError
+	}
+
 	switch e := err.(type) {
 	case *datavalidator.ValidationError:
 		switch e.Type {
@@ -241,11 +274,14 @@ func (aeh *ApplicationErrorHandler) categorizeError(err error) ExitCode {
 	}
 }
 
-// captureStackTrace captures the current call stack
-func captureStackTrace() []string {
-	const maxDepth = 10
+// captureStackTrace captures the current call stack, up to maxDepth frames.
+// Values below 1 are treated as 1.
+func captureStackTrace(maxDepth int) []string {
+	if maxDepth < 1 {
+		maxDepth = 1
+	}
 	stackTrace := make([]string, 0, maxDepth)
-	
+
 	for i := 2; i < maxDepth+2; i++ { // Skip captureStackTrace and CreateErrorContext
 		pc, file, line, ok := runtime.Caller(i)
 		if !ok {
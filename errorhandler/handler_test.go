@@ -1,12 +1,15 @@
 package errorhandler
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
 	"golang-taxi-fare/datavalidator"
 	"golang-taxi-fare/inputparser"
+	"golang-taxi-fare/loggingsystem"
 )
 
 func TestExitCode_String(t *testing.T) {
@@ -77,6 +80,10 @@ func TestNewErrorHandler(t *testing.T) {
 	if !appHandler.ExitOnError {
 		t.Error("Expected ExitOnError to be true by default")
 	}
+
+	if appHandler.StackDepth != 10 {
+		t.Errorf("Expected StackDepth to be 10 by default, got %d", appHandler.StackDepth)
+	}
 }
 
 func TestNewErrorHandlerWithOptions(t *testing.T) {
@@ -131,6 +138,31 @@ func TestApplicationErrorHandler_HandleError(t *testing.T) {
 	}
 }
 
+func TestApplicationErrorHandler_HandleErrorWithContext_RoutedThroughLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewErrorHandlerWithOptions(false, false).(*ApplicationErrorHandler)
+	handler.Logger = loggingsystem.NewLoggerWithOptions(&buf, loggingsystem.LevelError)
+
+	handler.HandleError(errors.New("something went wrong"))
+
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("Expected a JSON log line, got %q: %v", buf.String(), err)
+	}
+
+	if logEntry["level"] != "ERROR" {
+		t.Errorf("Expected level ERROR, got %v", logEntry["level"])
+	}
+
+	if logEntry["msg"] != "something went wrong" {
+		t.Errorf("Expected msg to be the error message, got %v", logEntry["msg"])
+	}
+
+	if logEntry["error_type"] != "general" {
+		t.Errorf("Expected error_type 'general', got %v", logEntry["error_type"])
+	}
+}
+
 func TestApplicationErrorHandler_HandleErrorWithContext(t *testing.T) {
 	handler := NewErrorHandlerWithOptions(false, false).(*ApplicationErrorHandler)
 	
@@ -251,6 +283,24 @@ func TestApplicationErrorHandler_CreateErrorContextWithStackTrace(t *testing.T)
 	}
 }
 
+func TestApplicationErrorHandler_StackDepth(t *testing.T) {
+	handler := NewErrorHandlerWithOptions(true, false).(*ApplicationErrorHandler)
+	handler.StackDepth = 3
+
+	ctx := handler.CreateErrorContext(errors.New("test error"), nil)
+
+	if len(ctx.StackTrace) > 3 {
+		t.Errorf("Expected at most 3 stack frames, got %d", len(ctx.StackTrace))
+	}
+
+	t.Run("values below 1 are treated as 1", func(t *testing.T) {
+		frames := captureStackTrace(0)
+		if len(frames) > 1 {
+			t.Errorf("Expected at most 1 stack frame, got %d", len(frames))
+		}
+	})
+}
+
 func TestCategorizeError(t *testing.T) {
 	handler := NewErrorHandlerWithOptions(false, false).(*ApplicationErrorHandler)
 	
@@ -4,7 +4,8 @@ package farecalculator
 
 import (
 	"fmt"
-	
+	"time"
+
 	"golang-taxi-fare/models"
 	"github.com/shopspring/decimal"
 )
@@ -29,6 +30,79 @@ var (
 	ExtendedUnit = decimal.NewFromInt(350) // meters per fare unit
 )
 
+// UnitRoundingMode controls how a partial fare unit straddling a tier
+// boundary (e.g. 9000m / 400m = 22.5 units) is rounded into a whole billable
+// unit.
+type UnitRoundingMode int
+
+const (
+	// RoundUnitsUp rounds any partial unit up to a full unit (the default,
+	// matching the historical behavior of this calculator).
+	RoundUnitsUp UnitRoundingMode = iota
+
+	// RoundUnitsHalfEven rounds to the nearest whole unit, breaking exact
+	// halves towards the even unit (banker's rounding).
+	RoundUnitsHalfEven
+
+	// RoundUnitsDown truncates any partial unit, charging only for whole
+	// units actually travelled.
+	RoundUnitsDown
+)
+
+// SurchargeBasis selects which of a trip's records determines whether the
+// night surcharge window applies.
+type SurchargeBasis int
+
+const (
+	// BasisBoarding applies the night surcharge window to the first record's
+	// timestamp (the default, matching the common rule that the surcharge is
+	// fixed at the start of the trip).
+	BasisBoarding SurchargeBasis = iota
+
+	// BasisAlighting applies the night surcharge window to the last record's
+	// timestamp, for rules that key the surcharge off when the trip ends
+	// rather than when it begins.
+	BasisAlighting
+)
+
+// String returns a human-readable description of the surcharge basis
+func (sb SurchargeBasis) String() string {
+	switch sb {
+	case BasisAlighting:
+		return "alighting"
+	default:
+		return "boarding"
+	}
+}
+
+// ceilUnitsWithGrace returns the number of whole units to charge for
+// distance travelled into units of size unitSize, rounding a partial unit up
+// unless the leftover distance into it is within tc.PartialUnitGraceMeters,
+// in which case the partial unit is waived entirely.
+func (tc *TaxiCalculator) ceilUnitsWithGrace(distance, unitSize decimal.Decimal) decimal.Decimal {
+	wholeUnits := distance.Div(unitSize).Floor()
+	leftover := distance.Sub(wholeUnits.Mul(unitSize))
+	if leftover.IsZero() {
+		return wholeUnits
+	}
+	if !tc.PartialUnitGraceMeters.IsZero() && leftover.LessThanOrEqual(tc.PartialUnitGraceMeters) {
+		return wholeUnits
+	}
+	return wholeUnits.Add(decimal.NewFromInt(1))
+}
+
+// round applies the receiver's rounding policy to a fractional unit count
+func (m UnitRoundingMode) round(units decimal.Decimal) decimal.Decimal {
+	switch m {
+	case RoundUnitsHalfEven:
+		return units.RoundBank(0)
+	case RoundUnitsDown:
+		return units.RoundFloor(0)
+	default:
+		return units.RoundCeil(0)
+	}
+}
+
 // FareBreakdown provides detailed breakdown of fare calculation components
 type FareBreakdown struct {
 	// BaseFareAmount is the base fare portion (400 yen for ≤1km)
@@ -45,13 +119,43 @@ type FareBreakdown struct {
 	
 	// Distance is the total distance used for calculation
 	Distance decimal.Decimal `json:"distance"`
+
+	// StandardApplied is true when StandardFareAmount is greater than zero,
+	// i.e. the trip travelled beyond the base distance. Saves clients from
+	// comparing differently-scaled decimals to zero.
+	StandardApplied bool `json:"standard_applied"`
+
+	// ExtendedApplied is true when ExtendedFareAmount is greater than zero,
+	// i.e. the trip crossed into the extended (>10km) rate band.
+	ExtendedApplied bool `json:"extended_applied"`
+
+	// ServiceChargeAmount is the percentage-based service charge applied on
+	// top of the fare subtotal, per TaxiCalculator.ServiceChargePercent.
+	// Zero when ServiceChargePercent is unset.
+	ServiceChargeAmount decimal.Decimal `json:"service_charge_amount"`
+
+	// NightSurchargeAmount is the percentage-based night surcharge applied on
+	// top of the fare subtotal (including ServiceChargeAmount), per
+	// TaxiCalculator.NightSurchargePercent. Zero when NightSurchargePercent
+	// is unset, or when the record selected by SurchargeBasis falls outside
+	// the configured night window or has a zero Timestamp. Only set by CalculateFromRecords, since
+	// CalculateFare has no record timestamps to consult.
+	NightSurchargeAmount decimal.Decimal `json:"night_surcharge_amount"`
+
+	// ServiceFeeAmount is the flat per-trip fee added on top of the fare
+	// total, per TaxiCalculator.ServiceFee. Unlike ServiceChargeAmount
+	// (percentage-based) it does not scale with the fare subtotal, and
+	// unlike BaseFareAmount it is charged once per trip regardless of
+	// distance rather than only for travel within the base distance. Zero
+	// when ServiceFee is unset.
+	ServiceFeeAmount decimal.Decimal `json:"service_fee_amount"`
 }
 
 // String implements the Stringer interface for debugging
 func (fb FareBreakdown) String() string {
-	return fmt.Sprintf("FareBreakdown{Distance: %s, Base: %s, Standard: %s, Extended: %s, Total: %s}",
-		fb.Distance.StringFixed(1), fb.BaseFareAmount.String(), 
-		fb.StandardFareAmount.String(), fb.ExtendedFareAmount.String(), fb.TotalFare.String())
+	return fmt.Sprintf("FareBreakdown{Distance: %s, Base: %s, Standard: %s, Extended: %s, ServiceFee: %s, Total: %s}",
+		fb.Distance.StringFixed(1), fb.BaseFareAmount.String(),
+		fb.StandardFareAmount.String(), fb.ExtendedFareAmount.String(), fb.ServiceFeeAmount.String(), fb.TotalFare.String())
 }
 
 // Calculator defines the interface for fare calculation operations
@@ -63,26 +167,395 @@ type Calculator interface {
 	CalculateFromRecords(records []models.DistanceRecord) models.FareCalculation
 }
 
+// TripDistanceStrategy selects how CalculateFromRecords derives a trip's
+// billable travel distance from its records.
+type TripDistanceStrategy int
+
+const (
+	// StrategyMinMax derives travel distance as the difference between the
+	// maximum and minimum reported distance across the sequence (the
+	// default, matching the historical behavior of this calculator). Suits
+	// odometer readings that may dip slightly due to GPS noise without
+	// under- or over-counting the distance actually covered.
+	StrategyMinMax TripDistanceStrategy = iota
+
+	// StrategyFirstLast derives travel distance as simply the last record's
+	// distance minus the first's, for sources where only the trip's
+	// endpoints matter (e.g. a cumulative trip meter that resets per trip)
+	// and any mid-trip dip should not be counted at all.
+	StrategyFirstLast
+
+	// StrategySumDeltas derives travel distance as the sum of every positive
+	// consecutive delta in the sequence, ignoring negative deltas, for noisy
+	// GPS sources where the reported position can jitter backwards between
+	// readings but forward progress should still accumulate rather than be
+	// cancelled out.
+	StrategySumDeltas
+)
+
+// String returns a human-readable description of the distance strategy
+func (s TripDistanceStrategy) String() string {
+	switch s {
+	case StrategyFirstLast:
+		return "first-last"
+	case StrategySumDeltas:
+		return "sum-deltas"
+	default:
+		return "min-max"
+	}
+}
+
+// rolloverBandFraction is the fraction of OdometerMax, at both ends of the
+// odometer's range, within which a mileage decrease is treated as a
+// plausible rollover rather than a genuine reading error. Mirrors
+// datavalidator's constant of the same name and meaning.
+var rolloverBandFraction = decimal.NewFromFloat(0.1)
+
+// isOdometerRollover reports whether previous -> current looks like an
+// odometer rollover: OdometerMax is set, previous is within the top
+// rolloverBandFraction of the odometer's range, and current is within the
+// bottom rolloverBandFraction. The caller is expected to already know
+// current.Distance < previous.Distance.
+func (tc *TaxiCalculator) isOdometerRollover(previous, current models.DistanceRecord) bool {
+	if tc.OdometerMax.IsZero() {
+		return false
+	}
+	band := tc.OdometerMax.Mul(rolloverBandFraction)
+	nearMax := previous.Distance.GreaterThanOrEqual(tc.OdometerMax.Sub(band))
+	nearZero := current.Distance.LessThanOrEqual(band)
+	return nearMax && nearZero
+}
+
+// unrollOdometer returns records with each Distance replaced by a running
+// total that reinterprets any isOdometerRollover decrease as (OdometerMax -
+// previous) + current distance travelled, so tripDistance's strategies
+// below see a single monotonically-increasing reading across a rollover
+// instead of a large negative jump. Returns records unchanged when
+// OdometerMax is zero.
+func (tc *TaxiCalculator) unrollOdometer(records []models.DistanceRecord) []models.DistanceRecord {
+	if tc.OdometerMax.IsZero() {
+		return records
+	}
+	unrolled := make([]models.DistanceRecord, len(records))
+	unrolled[0] = records[0]
+	cumulative := records[0].Distance
+	for i := 1; i < len(records); i++ {
+		previous, current := records[i-1], records[i]
+		delta := current.Distance.Sub(previous.Distance)
+		if delta.IsNegative() && tc.isOdometerRollover(previous, current) {
+			delta = tc.OdometerMax.Sub(previous.Distance).Add(current.Distance)
+		}
+		cumulative = cumulative.Add(delta)
+		unrolled[i] = current
+		unrolled[i].Distance = cumulative
+	}
+	return unrolled
+}
+
+// tripDistance computes the billable travel distance for records according
+// to tc.DistanceStrategy. Assumes len(records) > 0.
+func (tc *TaxiCalculator) tripDistance(records []models.DistanceRecord) decimal.Decimal {
+	records = tc.unrollOdometer(records)
+	switch tc.DistanceStrategy {
+	case StrategyFirstLast:
+		return records[len(records)-1].Distance.Sub(records[0].Distance)
+	case StrategySumDeltas:
+		total := decimal.Zero
+		for i := 1; i < len(records); i++ {
+			delta := records[i].Distance.Sub(records[i-1].Distance)
+			if delta.GreaterThan(decimal.Zero) {
+				total = total.Add(delta)
+			}
+		}
+		return total
+	default: // StrategyMinMax
+		maxDistance := records[0].Distance
+		minDistance := records[0].Distance
+		for _, record := range records[1:] {
+			if record.Distance.GreaterThan(maxDistance) {
+				maxDistance = record.Distance
+			}
+			if record.Distance.LessThan(minDistance) {
+				minDistance = record.Distance
+			}
+		}
+		return maxDistance.Sub(minDistance)
+	}
+}
+
+// FareStrategy computes a FareBreakdown for a given travel distance,
+// independent of any particular tariff structure. TaxiCalculator.Strategy
+// holds one of these, letting CalculateFromRecords price a trip under a
+// radically different fare model (flat, zone-based, metered) without
+// growing TaxiCalculator's own set of tariff-specific fields for each one.
+// Users may implement their own strategy; see FlatRateStrategy for a sample.
+type FareStrategy interface {
+	// Fare calculates the fare breakdown for a given distance in meters.
+	Fare(distanceMeters decimal.Decimal) FareBreakdown
+}
+
+// meteredStrategy adapts a TaxiCalculator's own CalculateFare into a
+// FareStrategy, used as TaxiCalculator's default strategy so existing
+// callers keep the historical Japanese metered fare behavior without
+// opting into anything.
+type meteredStrategy struct {
+	tc *TaxiCalculator
+}
+
+// Fare implements FareStrategy by delegating to the wrapped calculator's
+// CalculateFare.
+func (ms meteredStrategy) Fare(distanceMeters decimal.Decimal) FareBreakdown {
+	return ms.tc.CalculateFare(distanceMeters)
+}
+
+// FlatRateStrategy is a sample FareStrategy charging a flat rate per
+// kilometer (or fraction thereof) travelled, with no base fare or tiered
+// distance bands, for callers wanting a simple flat-rate fare model instead
+// of the Japanese metered structure.
+type FlatRateStrategy struct {
+	// RatePerKm is the flat fare charged per kilometer, or fraction thereof,
+	// of travel distance.
+	RatePerKm decimal.Decimal
+}
+
+// Fare implements FareStrategy, charging RatePerKm for each kilometer (or
+// fraction thereof) of distanceMeters, rounded up to the nearest kilometer.
+// A negative distance owes nothing, matching CalculateFare's treatment of
+// negative distance.
+func (frs FlatRateStrategy) Fare(distanceMeters decimal.Decimal) FareBreakdown {
+	if distanceMeters.IsNegative() {
+		return FareBreakdown{Distance: distanceMeters, TotalFare: decimal.Zero}
+	}
+
+	units := distanceMeters.Div(decimal.NewFromInt(1000)).RoundCeil(0)
+	total := units.Mul(frs.RatePerKm)
+
+	return FareBreakdown{
+		Distance:       distanceMeters,
+		BaseFareAmount: total,
+		TotalFare:      total,
+	}
+}
+
 // TaxiCalculator implements the Calculator interface with Japanese taxi fare logic
-type TaxiCalculator struct{}
+type TaxiCalculator struct {
+	// MeterContinuesPastBase controls how the first standard-rate unit is
+	// charged immediately after the base distance boundary.
+	//
+	// When false (the default, matching the historical behavior of this
+	// calculator), the meter is treated as resetting at the base boundary: any
+	// distance past BaseDistance, however small, immediately rounds up to a
+	// full StandardUnit charge. At 1000.5m this charges one full 400m unit
+	// (40 yen) for the 0.5m travelled past the 1km base distance.
+	//
+	// When true, the meter is treated as continuing past the boundary without
+	// resetting: a unit is only charged once a full StandardUnit has actually
+	// been travelled past the base distance. At 1000.5m this charges nothing
+	// extra, since less than 400m has elapsed since the base boundary.
+	MeterContinuesPastBase bool
+
+	// StandardUnitRounding controls how a partial standard-rate unit is
+	// rounded when MeterContinuesPastBase is false (the reset-at-boundary
+	// case). The zero value, RoundUnitsUp, matches the historical behavior
+	// of always rounding a partial unit up. It has no effect when
+	// MeterContinuesPastBase is true, since that mode already floors to
+	// whole units travelled.
+	StandardUnitRounding UnitRoundingMode
+
+	// DistanceFareRounding, when non-zero, rounds StandardFareAmount +
+	// ExtendedFareAmount to the nearest multiple of this increment (e.g.
+	// decimal.NewFromInt(40) to round to the nearest 40 yen), rather than
+	// charging the exact per-tier ceiled-unit amounts. This is applied after
+	// per-tier unit rounding (StandardUnitRounding / MeterContinuesPastBase),
+	// which determines the ceiled-unit amounts being rounded, and before
+	// TotalFare is summed, so it changes TotalFare directly rather than
+	// rounding it a second time. Any adjustment is absorbed into
+	// StandardFareAmount so ExtendedFareAmount still reflects exact
+	// extended-tier travel. The zero value disables this rounding, matching
+	// the historical exact-unit behavior.
+	DistanceFareRounding decimal.Decimal
+
+	// ServiceChargePercent, when non-zero, adds a percentage-based service
+	// charge on top of the fare subtotal (base + standard + extended, after
+	// DistanceFareRounding), surfaced as FareBreakdown.ServiceChargeAmount
+	// and included in TotalFare. Expressed as a whole percentage (e.g.
+	// decimal.NewFromInt(10) for 10%). When NightSurchargePercent also
+	// applies, CalculateFromRecords computes the night surcharge after this
+	// charge, on a subtotal that includes it; the service charge itself is
+	// not computed on a surcharge-inclusive subtotal. The zero value
+	// disables the charge.
+	ServiceChargePercent decimal.Decimal
+
+	// NightSurchargePercent, when non-zero, adds a percentage-based
+	// surcharge on top of the fare subtotal (base + standard + extended +
+	// service charge) in CalculateFromRecords, when the record chosen by
+	// SurchargeBasis falls within the night window defined by
+	// NightSurchargeStartHour/NightSurchargeEndHour. Expressed as a whole
+	// percentage (e.g. decimal.NewFromInt(20) for 20%). Has no effect on
+	// CalculateFare, which has no record timestamps to consult. The zero
+	// value disables the surcharge.
+	NightSurchargePercent decimal.Decimal
+
+	// NightSurchargeStartHour and NightSurchargeEndHour define the night
+	// window as local hours-of-day [StartHour, EndHour), wrapping past
+	// midnight when StartHour > EndHour (e.g. 22 and 5 for 22:00-05:00).
+	// Ignored when NightSurchargePercent is zero.
+	NightSurchargeStartHour int
+	NightSurchargeEndHour   int
+
+	// SurchargeBasis selects which record's timestamp is checked against the
+	// night window: the first record (BasisBoarding, the default) or the
+	// last record (BasisAlighting). Ignored when NightSurchargePercent is
+	// zero.
+	SurchargeBasis SurchargeBasis
+
+	// ServiceFee, when non-zero, adds a flat per-trip fee on top of
+	// TotalFare, surfaced separately as FareBreakdown.ServiceFeeAmount. It
+	// is charged exactly once per trip, regardless of distance, unlike
+	// BaseFareAmount (which only covers travel up to BaseDistance) and
+	// ServiceChargePercent (which scales with the fare subtotal rather than
+	// being a fixed amount). Models a fixed ride-hailing booking fee. The
+	// zero value disables it, matching the historical behavior of this
+	// calculator.
+	ServiceFee decimal.Decimal
+
+	// PartialUnitGraceMeters, when non-zero, waives the round-up of a
+	// partial standard- or extended-rate unit when the leftover distance
+	// travelled into that unit is at or below this threshold, rather than
+	// charging a full unit for any entry however small. For example, with
+	// PartialUnitGraceMeters of 50, a trip travelling 1010m (10m into the
+	// first standard unit past the base distance) owes no standard-rate
+	// charge, while one travelling 1100m (100m into that unit) still owes
+	// the full unit. Only affects unit boundaries that would otherwise
+	// round up: the extended tier's Ceil, and the standard tier's reset-at-
+	// boundary rounding when StandardUnitRounding is RoundUnitsUp (the
+	// default); it has no effect when MeterContinuesPastBase is true or
+	// StandardUnitRounding is not RoundUnitsUp, since neither rounds a
+	// partial unit up to begin with. The zero value disables this,
+	// matching the historical round-up-on-any-entry behavior.
+	PartialUnitGraceMeters decimal.Decimal
+
+	// ChargeBaseOnBoarding, when true, makes CalculateFromRecords charge the
+	// base fare for a trip with at least two records spanning a nonzero
+	// duration but zero net travel distance (odometer unchanged), rather
+	// than the zero fare CalculateFare(0) would otherwise produce. This
+	// models a passenger who boards and is driven in place, or boards and
+	// is dropped off without the odometer advancing, as still owing the
+	// minimum fare. Has no effect on CalculateFare directly, and no effect
+	// when travel distance is nonzero. Defaults to false, preserving the
+	// historical zero-fare behavior for a zero-distance trip.
+	ChargeBaseOnBoarding bool
+
+	// TariffName and TariffVersion identify the fare table this calculator
+	// implements, carried through to every models.FareCalculation it
+	// produces (see models.FareCalculation.TariffName/TariffVersion) so
+	// output that may show multiple tariffs can state which one produced a
+	// given fare. NewCalculator and NewCalculatorWithOptions default these
+	// to "jp-standard" and "v1", describing the Japanese fare structure
+	// this package implements; set them explicitly to identify a
+	// differently-configured calculator (e.g. one with a custom
+	// ServiceChargePercent) as a distinct tariff.
+	TariffName    string
+	TariffVersion string
+
+	// Strategy determines how CalculateFromRecords prices the trip's travel
+	// distance. The zero value (nil) uses an internal default strategy
+	// wrapping tc's own CalculateFare, preserving the historical Japanese
+	// metered behavior; set Strategy to a different FareStrategy (e.g.
+	// FlatRateStrategy) to price trips under an entirely different fare
+	// model without touching this struct's other, metered-specific fields.
+	// ChargeBaseOnBoarding and the night-surcharge fields remain
+	// metered-specific refinements layered on top of whatever Strategy
+	// returns; they only take effect in the same cases they always have
+	// (zero-distance boarding, and a configured night window) regardless of
+	// which strategy is in use. Has no effect on CalculateFare, which always
+	// uses the Japanese metered logic described by this struct's other
+	// fields.
+	Strategy FareStrategy
+
+	// DistanceStrategy selects how CalculateFromRecords derives a trip's
+	// billable travel distance from its records: by extremes (StrategyMinMax,
+	// the zero value and default, preserving historical compatibility), by
+	// endpoints only (StrategyFirstLast), or by summing positive deltas
+	// (StrategySumDeltas). Has no effect on CalculateFare or EstimateFare,
+	// which take a distance directly rather than deriving one from records.
+	DistanceStrategy TripDistanceStrategy
+
+	// OdometerMax, when non-zero, makes tripDistance odometer-rollover
+	// aware: a decrease that looks like a rollover (previous near
+	// OdometerMax, current near zero) contributes (OdometerMax - previous)
+	// + current to travel distance instead of being read at face value,
+	// under every DistanceStrategy. Mirrors
+	// datavalidator.DataValidator.OdometerMax; set both to the same value
+	// so a sequence ValidateSequence accepts as a rollover is also billed
+	// correctly by CalculateFromRecords, rather than accepting the
+	// sequence but then billing it as if the rollover were a genuine,
+	// enormous jump in distance. The zero value (default) disables this,
+	// preserving the historical behavior of reading Distance values as an
+	// absolute, monotonically increasing odometer.
+	OdometerMax decimal.Decimal
+}
+
+// strategy returns tc.Strategy if set, or a default strategy wrapping tc's
+// own CalculateFare otherwise, so CalculateFromRecords always has a
+// FareStrategy to delegate to.
+func (tc *TaxiCalculator) strategy() FareStrategy {
+	if tc.Strategy != nil {
+		return tc.Strategy
+	}
+	return meteredStrategy{tc: tc}
+}
 
 // NewCalculator creates a new TaxiCalculator instance
 func NewCalculator() Calculator {
-	return &TaxiCalculator{}
+	return &TaxiCalculator{
+		TariffName:    "jp-standard",
+		TariffVersion: "v1",
+	}
 }
 
-// CalculateFare calculates the fare for a given distance in meters using Japanese taxi fare structure
+// NewCalculatorWithOptions creates a new TaxiCalculator with custom options
+func NewCalculatorWithOptions(meterContinuesPastBase bool) Calculator {
+	return &TaxiCalculator{
+		MeterContinuesPastBase: meterContinuesPastBase,
+		TariffName:             "jp-standard",
+		TariffVersion:          "v1",
+	}
+}
+
+// CalculateFare calculates the fare for a given distance in meters using
+// Japanese taxi fare structure.
+//
+// Unit ceiling semantics: both the standard-rate and extended-rate tiers
+// bill whole units of travel (400m and 350m respectively), rounded up via
+// decimal's Ceil/RoundCeil on the exact unit count (distance / unit size).
+// A distance landing exactly on a unit boundary is NOT rounded up to the
+// next unit — e.g. exactly 400m of standard-rate travel (1400m total, 1km
+// base + 400m) is one unit, not two — since Ceil leaves an already-integral
+// value unchanged. Only distance strictly past a boundary (e.g. 400.001m,
+// 1400.001m total) rounds up to the next unit. This matches a real meter,
+// which only ticks once a unit has actually started.
 func (tc *TaxiCalculator) CalculateFare(distanceMeters decimal.Decimal) FareBreakdown {
 	var baseFareAmount, standardFareAmount, extendedFareAmount decimal.Decimal
 	
-	// Handle negative or zero distance
-	if distanceMeters.IsNegative() || distanceMeters.IsZero() {
+	// Handle negative distance
+	if distanceMeters.IsNegative() {
 		return FareBreakdown{
 			Distance: distanceMeters,
 			TotalFare: decimal.Zero,
 		}
 	}
-	
+
+	// Zero distance still owes the flat per-trip ServiceFee, if configured,
+	// since it isn't tied to travel the way the base fare is.
+	if distanceMeters.IsZero() {
+		return FareBreakdown{
+			Distance:         distanceMeters,
+			TotalFare:        tc.ServiceFee,
+			ServiceFeeAmount: tc.ServiceFee,
+		}
+	}
+
 	// Base fare: 400 yen for distance ≤ 1km
 	if distanceMeters.LessThanOrEqual(BaseDistance) {
 		baseFareAmount = BaseFare
@@ -97,8 +570,18 @@ func (tc *TaxiCalculator) CalculateFare(distanceMeters decimal.Decimal) FareBrea
 		}
 		
 		if standardDistance.GreaterThan(decimal.Zero) {
-			// Calculate number of 400m units (rounded up)
-			standardUnits := standardDistance.Div(StandardUnit).Ceil()
+			// Calculate number of 400m units. By default the meter resets at
+			// the base boundary, so any partial unit rounds up immediately;
+			// with MeterContinuesPastBase a unit is only charged once fully
+			// travelled.
+			var standardUnits decimal.Decimal
+			if tc.MeterContinuesPastBase {
+				standardUnits = standardDistance.Div(StandardUnit).Floor()
+			} else if tc.StandardUnitRounding == RoundUnitsUp && !tc.PartialUnitGraceMeters.IsZero() {
+				standardUnits = tc.ceilUnitsWithGrace(standardDistance, StandardUnit)
+			} else {
+				standardUnits = tc.StandardUnitRounding.round(standardDistance.Div(StandardUnit))
+			}
 			standardFareAmount = standardUnits.Mul(StandardRate)
 		}
 		
@@ -106,21 +589,262 @@ func (tc *TaxiCalculator) CalculateFare(distanceMeters decimal.Decimal) FareBrea
 		if remainingDistance.GreaterThan(StandardThreshold.Sub(BaseDistance)) {
 			extendedDistance := remainingDistance.Sub(StandardThreshold.Sub(BaseDistance))
 			if extendedDistance.GreaterThan(decimal.Zero) {
-				// Calculate number of 350m units (rounded up)
-				extendedUnits := extendedDistance.Div(ExtendedUnit).Ceil()
+				// Calculate number of 350m units (rounded up, subject to
+				// PartialUnitGraceMeters)
+				extendedUnits := tc.ceilUnitsWithGrace(extendedDistance, ExtendedUnit)
 				extendedFareAmount = extendedUnits.Mul(ExtendedRate)
 			}
 		}
 	}
 	
-	totalFare := baseFareAmount.Add(standardFareAmount).Add(extendedFareAmount)
-	
+	// Round the combined distance fare (standard + extended tiers) to the
+	// nearest fare increment, if configured. The adjustment is absorbed into
+	// standardFareAmount so extendedFareAmount keeps reflecting exact
+	// extended-tier travel.
+	if !tc.DistanceFareRounding.IsZero() {
+		distanceFare := standardFareAmount.Add(extendedFareAmount)
+		roundedDistanceFare := distanceFare.Div(tc.DistanceFareRounding).Round(0).Mul(tc.DistanceFareRounding)
+		standardFareAmount = roundedDistanceFare.Sub(extendedFareAmount)
+	}
+
+	subtotal := baseFareAmount.Add(standardFareAmount).Add(extendedFareAmount)
+
+	// Apply the percentage-based service charge on top of the subtotal, if configured
+	var serviceChargeAmount decimal.Decimal
+	if !tc.ServiceChargePercent.IsZero() {
+		serviceChargeAmount = subtotal.Mul(tc.ServiceChargePercent).Div(decimal.NewFromInt(100))
+	}
+
+	totalFare := subtotal.Add(serviceChargeAmount).Add(tc.ServiceFee)
+
 	return FareBreakdown{
-		BaseFareAmount:     baseFareAmount,
-		StandardFareAmount: standardFareAmount,
-		ExtendedFareAmount: extendedFareAmount,
-		TotalFare:          totalFare,
-		Distance:           distanceMeters,
+		BaseFareAmount:      baseFareAmount,
+		StandardFareAmount:  standardFareAmount,
+		ExtendedFareAmount:  extendedFareAmount,
+		ServiceChargeAmount: serviceChargeAmount,
+		ServiceFeeAmount:    tc.ServiceFee,
+		TotalFare:           totalFare,
+		Distance:            distanceMeters,
+		StandardApplied:     standardFareAmount.GreaterThan(decimal.Zero),
+		ExtendedApplied:     extendedFareAmount.GreaterThan(decimal.Zero),
+	}
+}
+
+// PublishTable computes the fare for each distance in distancesMeters using
+// calc, returning distance/fare string pairs (meters, yen) in the same order
+// as distancesMeters, suitable for embedding in a published fare table.
+func PublishTable(calc Calculator, distancesMeters []decimal.Decimal) [][2]string {
+	table := make([][2]string, len(distancesMeters))
+	for i, distance := range distancesMeters {
+		fare := calc.CalculateFare(distance)
+		table[i] = [2]string{distance.String(), fare.TotalFare.String()}
+	}
+	return table
+}
+
+// ExplainDelta returns a short, human-readable explanation of how the fare
+// changes between two odometer readings, noting any standard/extended band
+// crossing and the units added within it. It composes calc's breakdown at
+// both endpoints; this is a diagnostic helper for "why did the fare jump?"
+// support questions, not part of the core calculation path.
+func ExplainDelta(calc Calculator, fromMeters, toMeters decimal.Decimal) string {
+	fromFare := calc.CalculateFare(fromMeters)
+	toFare := calc.CalculateFare(toMeters)
+	deltaFare := toFare.TotalFare.Sub(fromFare.TotalFare)
+
+	switch {
+	case !fromFare.ExtendedApplied && toFare.ExtendedApplied:
+		extendedUnits := toFare.ExtendedFareAmount.Sub(fromFare.ExtendedFareAmount).Div(ExtendedRate)
+		return fmt.Sprintf("crossed from standard into extended band at %s km; added %s extended units (%s yen)",
+			StandardThreshold.Div(decimal.NewFromInt(1000)).String(), extendedUnits.String(), deltaFare.String())
+	case !fromFare.StandardApplied && toFare.StandardApplied:
+		standardUnits := toFare.StandardFareAmount.Sub(fromFare.StandardFareAmount).Div(StandardRate)
+		return fmt.Sprintf("crossed from base into standard band at %s km; added %s standard units (%s yen)",
+			BaseDistance.Div(decimal.NewFromInt(1000)).String(), standardUnits.String(), deltaFare.String())
+	default:
+		return fmt.Sprintf("fare changed by %s yen over %s m, no band crossing",
+			deltaFare.String(), toMeters.Sub(fromMeters).String())
+	}
+}
+
+// CalculateTripFare is a thin ergonomic wrapper over calc.CalculateFromRecords
+// for callers that already have a trip's start/end odometer readings and
+// don't want to build a []models.DistanceRecord slice just to get a
+// FareCalculation. It errors if endDistance is less than startDistance,
+// rather than silently returning a zero-distance fare. Timestamps are left
+// zero-valued, so any night-surcharge configured on calc never applies here;
+// callers needing the surcharge should go through CalculateFromRecords with
+// real timestamps instead.
+func CalculateTripFare(calc Calculator, startDistance, endDistance decimal.Decimal) (models.FareCalculation, error) {
+	if endDistance.LessThan(startDistance) {
+		return models.FareCalculation{}, fmt.Errorf("endDistance (%s) is less than startDistance (%s)",
+			endDistance.String(), startDistance.String())
+	}
+
+	return calc.CalculateFromRecords([]models.DistanceRecord{
+		{Distance: startDistance},
+		{Distance: endDistance},
+	}), nil
+}
+
+// FlatComparison holds the result of comparing a trip's metered fare against
+// a simple flat per-kilometer fare, via CompareToFlatRate.
+type FlatComparison struct {
+	// Distance is the spread between the largest and smallest record
+	// Distance, in meters. For a non-decreasing sequence this is the figure
+	// calc.CalculateFromRecords prices under the default StrategyMinMax; it
+	// does not follow a TaxiCalculator's DistanceStrategy or OdometerMax
+	// rollover handling.
+	Distance decimal.Decimal
+
+	// MeteredFare is calc.CalculateFromRecords(records).TotalFare.
+	MeteredFare decimal.Decimal
+
+	// FlatFare is Distance (converted to kilometers) multiplied by
+	// flatRatePerKm.
+	FlatFare decimal.Decimal
+
+	// Difference is MeteredFare minus FlatFare: positive when the metered
+	// fare costs the rider more than the flat rate would, negative when it
+	// costs less.
+	Difference decimal.Decimal
+}
+
+// CompareToFlatRate computes a trip's metered fare via calc.CalculateFromRecords
+// alongside what a simple flat per-kilometer fare would have charged for the
+// same distance, for product decisions about introducing flat pricing.
+func CompareToFlatRate(calc Calculator, records []models.DistanceRecord, flatRatePerKm decimal.Decimal) FlatComparison {
+	metered := calc.CalculateFromRecords(records)
+
+	var distance decimal.Decimal
+	if len(records) > 0 {
+		maxDistance := records[0].Distance
+		minDistance := records[0].Distance
+		for _, record := range records[1:] {
+			if record.Distance.GreaterThan(maxDistance) {
+				maxDistance = record.Distance
+			}
+			if record.Distance.LessThan(minDistance) {
+				minDistance = record.Distance
+			}
+		}
+		distance = maxDistance.Sub(minDistance)
+	}
+
+	flatFare := distance.Div(decimal.NewFromInt(1000)).Mul(flatRatePerKm)
+
+	return FlatComparison{
+		Distance:    distance,
+		MeteredFare: metered.TotalFare,
+		FlatFare:    flatFare,
+		Difference:  metered.TotalFare.Sub(flatFare),
+	}
+}
+
+// defaultCalculator backs the package-level Fare convenience function. It
+// uses NewCalculator's defaults (no night surcharge, no meter-continues-past-base
+// option), matching the "standard" tariff.
+var defaultCalculator = NewCalculator()
+
+// Fare is a stateless convenience wrapper around the default Calculator for
+// callers who just want a total fare for one distance and don't need to
+// configure tariff options or reuse a Calculator across calls. Callers that
+// need anything beyond the default rules (night surcharge, caching,
+// meter-continues-past-base) should use NewCalculator/NewCalculatorWithOptions
+// and the Calculator interface directly.
+func Fare(distanceMeters decimal.Decimal) decimal.Decimal {
+	return defaultCalculator.CalculateFare(distanceMeters).TotalFare
+}
+
+// MarginalRatePerMeter returns how much one additional meter of travel would
+// cost at atMeters of cumulative distance: zero for atMeters strictly below
+// BaseDistance (still within the base fare), StandardRate/StandardUnit from
+// BaseDistance up to (not including) StandardThreshold, and
+// ExtendedRate/ExtendedUnit from StandardThreshold onward. Since billing is
+// per-unit rather than per-meter, this is the unit's rate averaged over its
+// whole length, not the exact cost of the very next meter — which, just
+// past a unit boundary, is actually the whole next unit's fare. Useful for a
+// live meter display that wants to show the current per-meter rate without
+// computing a full FareBreakdown. Negative atMeters is treated as zero.
+func MarginalRatePerMeter(atMeters decimal.Decimal) decimal.Decimal {
+	if atMeters.IsNegative() {
+		atMeters = decimal.Zero
+	}
+	switch {
+	case atMeters.LessThan(BaseDistance):
+		return decimal.Zero
+	case atMeters.LessThan(StandardThreshold):
+		return StandardRate.Div(StandardUnit)
+	default:
+		return ExtendedRate.Div(ExtendedUnit)
+	}
+}
+
+// inNightWindow reports whether t's local hour falls within the night window
+// [startHour, endHour), wrapping past midnight when startHour > endHour. A
+// window with startHour == endHour never matches.
+func inNightWindow(t time.Time, startHour, endHour int) bool {
+	if startHour == endHour {
+		return false
+	}
+	hour := t.Hour()
+	if startHour < endHour {
+		return hour >= startHour && hour < endHour
+	}
+	return hour >= startHour || hour < endHour
+}
+
+// applyNightSurcharge adds NightSurchargePercent's surcharge to fareBreakdown
+// when the record selected by SurchargeBasis falls within the configured
+// night window, leaving fareBreakdown unchanged otherwise, including when
+// that record carries a zero Timestamp (no time of day to evaluate).
+func (tc *TaxiCalculator) applyNightSurcharge(fareBreakdown FareBreakdown, records []models.DistanceRecord) FareBreakdown {
+	if tc.NightSurchargePercent.IsZero() {
+		return fareBreakdown
+	}
+
+	basisRecord := records[0]
+	if tc.SurchargeBasis == BasisAlighting {
+		basisRecord = records[len(records)-1]
+	}
+	if basisRecord.Timestamp.IsZero() || !inNightWindow(basisRecord.Timestamp, tc.NightSurchargeStartHour, tc.NightSurchargeEndHour) {
+		return fareBreakdown
+	}
+
+	subtotal := fareBreakdown.BaseFareAmount.Add(fareBreakdown.StandardFareAmount).
+		Add(fareBreakdown.ExtendedFareAmount).Add(fareBreakdown.ServiceChargeAmount)
+	fareBreakdown.NightSurchargeAmount = subtotal.Mul(tc.NightSurchargePercent).Div(decimal.NewFromInt(100))
+	fareBreakdown.TotalFare = fareBreakdown.TotalFare.Add(fareBreakdown.NightSurchargeAmount)
+	return fareBreakdown
+}
+
+// hasNonzeroDuration reports whether records span more than one distinct
+// timestamp, i.e. time actually passed between the first and last record.
+func hasNonzeroDuration(records []models.DistanceRecord) bool {
+	if len(records) < 2 {
+		return false
+	}
+	return !records[len(records)-1].Timestamp.Equal(records[0].Timestamp)
+}
+
+// boardedBaseFareBreakdown returns the FareBreakdown for a zero-distance
+// trip that still charges the base fare, used by CalculateFromRecords when
+// ChargeBaseOnBoarding is enabled. The base fare, any configured service
+// charge on it, and the flat ServiceFee all apply, whereas CalculateFare's
+// own zero-distance branch charges only the ServiceFee; there is no
+// standard/extended-tier distance to charge for.
+func (tc *TaxiCalculator) boardedBaseFareBreakdown(distance decimal.Decimal) FareBreakdown {
+	var serviceChargeAmount decimal.Decimal
+	if !tc.ServiceChargePercent.IsZero() {
+		serviceChargeAmount = BaseFare.Mul(tc.ServiceChargePercent).Div(decimal.NewFromInt(100))
+	}
+
+	return FareBreakdown{
+		Distance:            distance,
+		BaseFareAmount:      BaseFare,
+		ServiceChargeAmount: serviceChargeAmount,
+		ServiceFeeAmount:    tc.ServiceFee,
+		TotalFare:           BaseFare.Add(serviceChargeAmount).Add(tc.ServiceFee),
 	}
 }
 
@@ -130,40 +854,91 @@ func (tc *TaxiCalculator) CalculateFromRecords(records []models.DistanceRecord)
 	// Handle empty records
 	if len(records) == 0 {
 		return models.FareCalculation{
-			BaseFare:     decimal.Zero,
-			DistanceFare: decimal.Zero,
-			TimeFare:     decimal.Zero,
-			TotalFare:    decimal.Zero,
+			BaseFare:      decimal.Zero,
+			DistanceFare:  decimal.Zero,
+			TimeFare:      decimal.Zero,
+			TotalFare:     decimal.Zero,
+			RoundingDelta: decimal.Zero,
+			TariffName:    tc.TariffName,
+			TariffVersion: tc.TariffVersion,
 		}
 	}
 	
-	// Find the maximum distance (assuming odometer readings)
-	maxDistance := records[0].Distance
-	minDistance := records[0].Distance
-	
-	for _, record := range records[1:] {
-		if record.Distance.GreaterThan(maxDistance) {
-			maxDistance = record.Distance
+	// Fast path: under the default StrategyMinMax, records are validated
+	// upstream to have non-decreasing mileage (see
+	// DataValidator.validateMileageProgression), so the first and last
+	// readings already give the total travel distance without scanning for
+	// min/max. When that distance falls within BaseDistance, price it
+	// directly and skip the scan entirely. A negative delta falls through
+	// to the full scan. Unvalidated input whose readings dip or peak between
+	// a first and last reading within BaseDistance of each other also takes
+	// this path, and is priced on last minus first rather than on the
+	// min/max spread the full scan would use. Other strategies always go
+	// through the general tripDistance path below, since they don't share
+	// this monotonic-input assumption. Also falls through whenever
+	// OdometerMax is set, since a negative firstLast delta may be a
+	// rollover that tripDistance's unrollOdometer needs to reinterpret
+	// rather than a simple non-monotonic reading.
+	firstLast := records[len(records)-1].Distance.Sub(records[0].Distance)
+	if tc.DistanceStrategy == StrategyMinMax && tc.OdometerMax.IsZero() && !firstLast.IsNegative() && firstLast.LessThanOrEqual(BaseDistance) {
+		baseBreakdown := tc.strategy().Fare(firstLast)
+		if firstLast.IsZero() && tc.ChargeBaseOnBoarding && hasNonzeroDuration(records) {
+			baseBreakdown = tc.boardedBaseFareBreakdown(firstLast)
 		}
-		if record.Distance.LessThan(minDistance) {
-			minDistance = record.Distance
+		fareBreakdown := tc.applyNightSurcharge(baseBreakdown, records)
+		return models.FareCalculation{
+			BaseFare:      fareBreakdown.BaseFareAmount,
+			DistanceFare:  fareBreakdown.StandardFareAmount.Add(fareBreakdown.ExtendedFareAmount),
+			TimeFare:      decimal.Zero,
+			TotalFare:     fareBreakdown.TotalFare,
+			RoundingDelta: fareBreakdown.TotalFare.Round(0).Sub(fareBreakdown.TotalFare),
+			TariffName:    tc.TariffName,
+			TariffVersion: tc.TariffVersion,
 		}
 	}
-	
-	// Calculate total travel distance
-	travelDistance := maxDistance.Sub(minDistance)
-	
+
+	// Calculate total travel distance per tc.DistanceStrategy
+	travelDistance := tc.tripDistance(records)
+
 	// Convert from kilometers to meters if needed
 	// Assuming input is in meters based on the large decimal values in tests
-	fareBreakdown := tc.CalculateFare(travelDistance)
-	
+	baseBreakdown := tc.strategy().Fare(travelDistance)
+	if travelDistance.IsZero() && tc.ChargeBaseOnBoarding && hasNonzeroDuration(records) {
+		baseBreakdown = tc.boardedBaseFareBreakdown(travelDistance)
+	}
+	fareBreakdown := tc.applyNightSurcharge(baseBreakdown, records)
+
 	// Map to FareCalculation struct
 	// Note: Japanese taxi fares typically don't separate time-based charges in this simple model
 	// All charges are distance-based, so TimeFare is zero
 	return models.FareCalculation{
-		BaseFare:     fareBreakdown.BaseFareAmount,
-		DistanceFare: fareBreakdown.StandardFareAmount.Add(fareBreakdown.ExtendedFareAmount),
-		TimeFare:     decimal.Zero, // No time-based fare in this implementation
-		TotalFare:    fareBreakdown.TotalFare,
+		BaseFare:      fareBreakdown.BaseFareAmount,
+		DistanceFare:  fareBreakdown.StandardFareAmount.Add(fareBreakdown.ExtendedFareAmount),
+		TimeFare:      decimal.Zero, // No time-based fare in this implementation
+		TotalFare:     fareBreakdown.TotalFare,
+		RoundingDelta: fareBreakdown.TotalFare.Round(0).Sub(fareBreakdown.TotalFare),
+		TariffName:    tc.TariffName,
+		TariffVersion: tc.TariffVersion,
+	}
+}
+
+// EstimateFare maps tc.CalculateFare's breakdown for a single distance value
+// into a models.FareCalculation, for callers quoting a fare from a
+// distance-only input (e.g. "how much for 12km?") who don't have a record
+// sequence with timestamps and don't want to fabricate one just to call
+// CalculateFromRecords. Equivalent to calling CalculateFromRecords with two
+// zero-timestamp records at 0 and distanceMeters (so no night surcharge
+// applies), except that EstimateFare always prices through tc.CalculateFare
+// and ignores any custom Strategy set on tc.
+func (tc *TaxiCalculator) EstimateFare(distanceMeters decimal.Decimal) models.FareCalculation {
+	fareBreakdown := tc.CalculateFare(distanceMeters)
+	return models.FareCalculation{
+		BaseFare:      fareBreakdown.BaseFareAmount,
+		DistanceFare:  fareBreakdown.StandardFareAmount.Add(fareBreakdown.ExtendedFareAmount),
+		TimeFare:      decimal.Zero,
+		TotalFare:     fareBreakdown.TotalFare,
+		RoundingDelta: fareBreakdown.TotalFare.Round(0).Sub(fareBreakdown.TotalFare),
+		TariffName:    tc.TariffName,
+		TariffVersion: tc.TariffVersion,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,92 @@
+package farecalculator
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+// cacheEntry holds a memoized CalculateFare result keyed by the distance's
+// string representation
+type cacheEntry struct {
+	key   string
+	value FareBreakdown
+}
+
+// CachingCalculator decorates a Calculator with an LRU memoization cache for
+// CalculateFare, keyed by the distance's string representation. It is safe
+// for concurrent use.
+type CachingCalculator struct {
+	inner      Calculator
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+// NewCachingCalculator wraps inner with an LRU cache of up to maxEntries
+// distinct distances. This speeds up batch workloads where the same travel
+// distance recurs across many trips. A non-positive maxEntries disables
+// caching and simply delegates every call to inner.
+func NewCachingCalculator(inner Calculator, maxEntries int) Calculator {
+	return &CachingCalculator{
+		inner:      inner,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// CalculateFare returns the cached FareBreakdown for distanceMeters if
+// present, otherwise computes it via the inner Calculator and caches the
+// result, evicting the least recently used entry if the cache is full
+func (cc *CachingCalculator) CalculateFare(distanceMeters decimal.Decimal) FareBreakdown {
+	if cc.maxEntries <= 0 {
+		return cc.inner.CalculateFare(distanceMeters)
+	}
+
+	key := distanceMeters.String()
+
+	cc.mu.Lock()
+	if elem, ok := cc.entries[key]; ok {
+		cc.order.MoveToFront(elem)
+		value := elem.Value.(*cacheEntry).value
+		cc.mu.Unlock()
+		return value
+	}
+	cc.mu.Unlock()
+
+	value := cc.inner.CalculateFare(distanceMeters)
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	// Another goroutine may have computed and inserted the same key while we
+	// were calling inner without the lock held; prefer its entry.
+	if elem, ok := cc.entries[key]; ok {
+		cc.order.MoveToFront(elem)
+		return elem.Value.(*cacheEntry).value
+	}
+
+	elem := cc.order.PushFront(&cacheEntry{key: key, value: value})
+	cc.entries[key] = elem
+
+	if cc.order.Len() > cc.maxEntries {
+		oldest := cc.order.Back()
+		if oldest != nil {
+			cc.order.Remove(oldest)
+			delete(cc.entries, oldest.Value.(*cacheEntry).key)
+		}
+	}
+
+	return value
+}
+
+// CalculateFromRecords delegates to the inner Calculator without consulting
+// the cache; only CalculateFare calls made on cc are cached
+func (cc *CachingCalculator) CalculateFromRecords(records []models.DistanceRecord) models.FareCalculation {
+	return cc.inner.CalculateFromRecords(records)
+}
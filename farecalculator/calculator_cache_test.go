@@ -0,0 +1,131 @@
+package farecalculator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestCachingCalculator_MatchesUncachedResult(t *testing.T) {
+	inner := NewCalculator()
+	cached := NewCachingCalculator(inner, 10)
+
+	distances := []decimal.Decimal{
+		decimal.NewFromInt(500),
+		decimal.NewFromInt(1500),
+		decimal.NewFromInt(12000),
+		decimal.NewFromInt(1500), // repeated, should hit the cache
+	}
+
+	for _, d := range distances {
+		want := inner.CalculateFare(d)
+		got := cached.CalculateFare(d)
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("CalculateFare(%s) = %s, want %s", d.String(), got.TotalFare.String(), want.TotalFare.String())
+		}
+	}
+}
+
+func TestCachingCalculator_LRUEviction(t *testing.T) {
+	calls := 0
+	counting := &countingCalculator{inner: NewCalculator(), calls: &calls}
+	cached := NewCachingCalculator(counting, 2)
+
+	d1, d2, d3 := decimal.NewFromInt(500), decimal.NewFromInt(1500), decimal.NewFromInt(2500)
+
+	cached.CalculateFare(d1) // cache: [d1]
+	cached.CalculateFare(d2) // cache: [d2, d1]
+	cached.CalculateFare(d3) // evicts d1, cache: [d3, d2]
+
+	if calls != 3 {
+		t.Fatalf("Expected 3 inner calls after filling cache, got %d", calls)
+	}
+
+	cached.CalculateFare(d1) // d1 was evicted, should miss; this in turn evicts d2 (now the LRU entry)
+	if calls != 4 {
+		t.Errorf("Expected cache miss for evicted entry d1, inner calls = %d, want 4", calls)
+	}
+
+	cached.CalculateFare(d3) // d3 is still cached
+	if calls != 4 {
+		t.Errorf("Expected cache hit for d3, inner calls = %d, want 4", calls)
+	}
+
+	cached.CalculateFare(d2) // d2 was evicted by the d1 re-insertion above, should miss
+	if calls != 5 {
+		t.Errorf("Expected cache miss for evicted entry d2, inner calls = %d, want 5", calls)
+	}
+}
+
+func TestCachingCalculator_DisabledWhenMaxEntriesNonPositive(t *testing.T) {
+	calls := 0
+	counting := &countingCalculator{inner: NewCalculator(), calls: &calls}
+	cached := NewCachingCalculator(counting, 0)
+
+	d := decimal.NewFromInt(1500)
+	cached.CalculateFare(d)
+	cached.CalculateFare(d)
+
+	if calls != 2 {
+		t.Errorf("Expected caching disabled (every call delegates), inner calls = %d, want 2", calls)
+	}
+}
+
+func TestCachingCalculator_ConcurrentUse(t *testing.T) {
+	cached := NewCachingCalculator(NewCalculator(), 16)
+
+	var wg sync.WaitGroup
+	distances := []decimal.Decimal{
+		decimal.NewFromInt(500),
+		decimal.NewFromInt(1500),
+		decimal.NewFromInt(12000),
+	}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cached.CalculateFare(distances[i%len(distances)])
+		}(i)
+	}
+	wg.Wait()
+}
+
+// countingCalculator wraps a Calculator and counts CalculateFare calls, used
+// to observe cache hits/misses in tests
+type countingCalculator struct {
+	inner Calculator
+	calls *int
+}
+
+func (cc *countingCalculator) CalculateFare(distanceMeters decimal.Decimal) FareBreakdown {
+	*cc.calls++
+	return cc.inner.CalculateFare(distanceMeters)
+}
+
+func (cc *countingCalculator) CalculateFromRecords(records []models.DistanceRecord) models.FareCalculation {
+	panic("not used in this test")
+}
+
+func BenchmarkCachingCalculator_CacheHit(b *testing.B) {
+	cached := NewCachingCalculator(NewCalculator(), 1)
+	distance := decimal.NewFromInt(12345)
+
+	cached.CalculateFare(distance) // warm the cache
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cached.CalculateFare(distance)
+	}
+}
+
+func BenchmarkCachingCalculator_CacheMiss(b *testing.B) {
+	calc := NewCalculator()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		calc.CalculateFare(decimal.NewFromInt(12345))
+	}
+}
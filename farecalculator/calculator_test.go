@@ -1,11 +1,12 @@
 package farecalculator
 
 import (
+	"strings"
 	"testing"
 	"time"
 
-	"golang-taxi-fare/models"
 	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
 )
 
 func TestFareConstants(t *testing.T) {
@@ -13,27 +14,27 @@ func TestFareConstants(t *testing.T) {
 	if !BaseFare.Equal(decimal.NewFromInt(400)) {
 		t.Errorf("Expected BaseFare to be 400, got %s", BaseFare.String())
 	}
-	
+
 	if !BaseDistance.Equal(decimal.NewFromInt(1000)) {
 		t.Errorf("Expected BaseDistance to be 1000m, got %s", BaseDistance.String())
 	}
-	
+
 	if !StandardRate.Equal(decimal.NewFromInt(40)) {
 		t.Errorf("Expected StandardRate to be 40, got %s", StandardRate.String())
 	}
-	
+
 	if !StandardUnit.Equal(decimal.NewFromInt(400)) {
 		t.Errorf("Expected StandardUnit to be 400m, got %s", StandardUnit.String())
 	}
-	
+
 	if !StandardThreshold.Equal(decimal.NewFromInt(10000)) {
 		t.Errorf("Expected StandardThreshold to be 10000m, got %s", StandardThreshold.String())
 	}
-	
+
 	if !ExtendedRate.Equal(decimal.NewFromInt(40)) {
 		t.Errorf("Expected ExtendedRate to be 40, got %s", ExtendedRate.String())
 	}
-	
+
 	if !ExtendedUnit.Equal(decimal.NewFromInt(350)) {
 		t.Errorf("Expected ExtendedUnit to be 350m, got %s", ExtendedUnit.String())
 	}
@@ -44,7 +45,7 @@ func TestNewCalculator(t *testing.T) {
 	if calc == nil {
 		t.Error("Expected non-nil calculator")
 	}
-	
+
 	// Test that it implements the Calculator interface
 	_, ok := calc.(Calculator)
 	if !ok {
@@ -54,7 +55,7 @@ func TestNewCalculator(t *testing.T) {
 
 func TestTaxiCalculator_CalculateFare(t *testing.T) {
 	calc := NewCalculator().(*TaxiCalculator)
-	
+
 	tests := []struct {
 		name             string
 		distance         decimal.Decimal
@@ -123,32 +124,32 @@ func TestTaxiCalculator_CalculateFare(t *testing.T) {
 			name:             "12km (base + standard + extended)",
 			distance:         decimal.NewFromInt(12000),
 			expectedBase:     decimal.NewFromInt(400),
-			expectedStandard: decimal.NewFromInt(920), // 9000m = 23 units of 400m = 920
-			expectedExtended: decimal.NewFromInt(240), // 2000m = 6 units of 350m = 6 * 40 = 240
+			expectedStandard: decimal.NewFromInt(920),  // 9000m = 23 units of 400m = 920
+			expectedExtended: decimal.NewFromInt(240),  // 2000m = 6 units of 350m = 6 * 40 = 240
 			expectedTotal:    decimal.NewFromInt(1560), // 400 + 920 + 240 = 1560
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := calc.CalculateFare(tt.distance)
-			
+
 			if !result.Distance.Equal(tt.distance) {
 				t.Errorf("Expected distance %s, got %s", tt.distance.String(), result.Distance.String())
 			}
-			
+
 			if !result.BaseFareAmount.Equal(tt.expectedBase) {
 				t.Errorf("Expected base fare %s, got %s", tt.expectedBase.String(), result.BaseFareAmount.String())
 			}
-			
+
 			if !result.StandardFareAmount.Equal(tt.expectedStandard) {
 				t.Errorf("Expected standard fare %s, got %s", tt.expectedStandard.String(), result.StandardFareAmount.String())
 			}
-			
+
 			if !result.ExtendedFareAmount.Equal(tt.expectedExtended) {
 				t.Errorf("Expected extended fare %s, got %s", tt.expectedExtended.String(), result.ExtendedFareAmount.String())
 			}
-			
+
 			if !result.TotalFare.Equal(tt.expectedTotal) {
 				t.Errorf("Expected total fare %s, got %s", tt.expectedTotal.String(), result.TotalFare.String())
 			}
@@ -156,23 +157,688 @@ func TestTaxiCalculator_CalculateFare(t *testing.T) {
 	}
 }
 
+// TestTaxiCalculator_UnitCeilingBoundaries pins down CalculateFare's
+// exactly-at-boundary rounding: a distance landing exactly on a unit
+// boundary bills that unit, not one beyond it, while distance just past the
+// boundary rounds up to the next unit. See the ceiling semantics note on
+// CalculateFare.
+func TestTaxiCalculator_UnitCeilingBoundaries(t *testing.T) {
+	calc := NewCalculator().(*TaxiCalculator)
+
+	tests := []struct {
+		name          string
+		distance      decimal.Decimal
+		expectedUnits int64 // standard-rate (400m) units charged past the base fare
+	}{
+		{"exactly 1 standard unit past base (1400m)", decimal.NewFromInt(1400), 1},
+		{"just over 1 standard unit past base (1400.001m)", decimal.NewFromFloat(1400.001), 2},
+		{"exactly 2 standard units past base (1800m)", decimal.NewFromInt(1800), 2},
+		{"just over 2 standard units past base (1800.001m)", decimal.NewFromFloat(1800.001), 3},
+		{"exactly 10 standard units past base (5000m)", decimal.NewFromInt(5000), 10},
+		{"just over 10 standard units past base (5000.001m)", decimal.NewFromFloat(5000.001), 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calc.CalculateFare(tt.distance)
+			gotUnits := result.StandardFareAmount.Div(StandardRate).IntPart()
+			if gotUnits != tt.expectedUnits {
+				t.Errorf("distance %s: expected %d standard units, got %d (standard fare %s)",
+					tt.distance.String(), tt.expectedUnits, gotUnits, result.StandardFareAmount.String())
+			}
+		})
+	}
+}
+
+func TestTaxiCalculator_AppliedFlags(t *testing.T) {
+	calc := NewCalculator().(*TaxiCalculator)
+
+	tests := []struct {
+		name             string
+		distance         decimal.Decimal
+		expectedStandard bool
+		expectedExtended bool
+	}{
+		{
+			name:             "below 1km: neither band applied",
+			distance:         decimal.NewFromInt(500),
+			expectedStandard: false,
+			expectedExtended: false,
+		},
+		{
+			name:             "between 1-10km: standard applied, extended not",
+			distance:         decimal.NewFromInt(5000),
+			expectedStandard: true,
+			expectedExtended: false,
+		},
+		{
+			name:             "above 10km: both bands applied",
+			distance:         decimal.NewFromInt(12000),
+			expectedStandard: true,
+			expectedExtended: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calc.CalculateFare(tt.distance)
+
+			if result.StandardApplied != tt.expectedStandard {
+				t.Errorf("StandardApplied = %v, want %v", result.StandardApplied, tt.expectedStandard)
+			}
+			if result.ExtendedApplied != tt.expectedExtended {
+				t.Errorf("ExtendedApplied = %v, want %v", result.ExtendedApplied, tt.expectedExtended)
+			}
+		})
+	}
+}
+
+func TestTaxiCalculator_FractionalRatePrecision(t *testing.T) {
+	// Rates are package-level vars rather than constants specifically so a
+	// deployment can swap in a jurisdiction's own fractional-currency rates.
+	// CalculateFare must carry that precision through the full computation
+	// and only round once a caller formats the result for display.
+	originalRate := StandardRate
+	StandardRate = decimal.NewFromFloat(40.5)
+	defer func() { StandardRate = originalRate }()
+
+	calc := NewCalculator().(*TaxiCalculator)
+
+	result := calc.CalculateFare(decimal.NewFromInt(2200)) // 400 base + 3 units of 40.5
+	expectedTotal := decimal.NewFromFloat(521.5)
+	if !result.TotalFare.Equal(expectedTotal) {
+		t.Errorf("Expected precise total %s, got %s", expectedTotal.String(), result.TotalFare.String())
+	}
+
+	if result.TotalFare.IsInteger() {
+		t.Error("Expected TotalFare to retain fractional precision before display rounding")
+	}
+
+	if rounded := result.TotalFare.Round(0).IntPart(); rounded != 522 {
+		t.Errorf("Expected display rounding to 522 yen, got %d", rounded)
+	}
+}
+
+func TestTaxiCalculator_ServiceChargePercent(t *testing.T) {
+	t.Run("10% service charge on a known subtotal", func(t *testing.T) {
+		calc := &TaxiCalculator{ServiceChargePercent: decimal.NewFromInt(10)}
+
+		result := calc.CalculateFare(decimal.NewFromInt(1500)) // subtotal 480 (400 base + 80 standard)
+		expectedServiceCharge := decimal.NewFromInt(48)
+		expectedTotal := decimal.NewFromInt(528)
+
+		if !result.ServiceChargeAmount.Equal(expectedServiceCharge) {
+			t.Errorf("Expected ServiceChargeAmount %s, got %s", expectedServiceCharge.String(), result.ServiceChargeAmount.String())
+		}
+		if !result.TotalFare.Equal(expectedTotal) {
+			t.Errorf("Expected TotalFare %s, got %s", expectedTotal.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("zero value disables the charge", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		result := calc.CalculateFare(decimal.NewFromInt(1500))
+
+		if !result.ServiceChargeAmount.IsZero() {
+			t.Errorf("Expected zero ServiceChargeAmount by default, got %s", result.ServiceChargeAmount.String())
+		}
+	})
+}
+
+func TestTaxiCalculator_ServiceFee(t *testing.T) {
+	t.Run("flat fee adds to the total and appears separately in the breakdown", func(t *testing.T) {
+		calc := &TaxiCalculator{ServiceFee: decimal.NewFromInt(50)}
+
+		result := calc.CalculateFare(decimal.NewFromInt(1500)) // subtotal 480 (400 base + 80 standard)
+		expectedTotal := decimal.NewFromInt(530)
+
+		if !result.ServiceFeeAmount.Equal(decimal.NewFromInt(50)) {
+			t.Errorf("Expected ServiceFeeAmount 50, got %s", result.ServiceFeeAmount.String())
+		}
+		if !result.TotalFare.Equal(expectedTotal) {
+			t.Errorf("Expected TotalFare %s, got %s", expectedTotal.String(), result.TotalFare.String())
+		}
+
+		summary := result.String()
+		if !containsString(summary, "ServiceFee: 50") {
+			t.Errorf("Expected the debug summary to show the service fee separately, got: %s", summary)
+		}
+	})
+
+	t.Run("charged once per trip even for a zero-distance trip", func(t *testing.T) {
+		calc := &TaxiCalculator{ServiceFee: decimal.NewFromInt(50)}
+
+		result := calc.CalculateFare(decimal.Zero)
+
+		if !result.ServiceFeeAmount.Equal(decimal.NewFromInt(50)) {
+			t.Errorf("Expected ServiceFeeAmount 50 even for zero distance, got %s", result.ServiceFeeAmount.String())
+		}
+		if !result.TotalFare.Equal(decimal.NewFromInt(50)) {
+			t.Errorf("Expected TotalFare 50 for a zero-distance trip, got %s", result.TotalFare.String())
+		}
+	})
+
+	t.Run("zero value disables the fee", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		result := calc.CalculateFare(decimal.NewFromInt(1500))
+
+		if !result.ServiceFeeAmount.IsZero() {
+			t.Errorf("Expected zero ServiceFeeAmount by default, got %s", result.ServiceFeeAmount.String())
+		}
+	})
+}
+
+func TestTaxiCalculator_SurchargeBasis(t *testing.T) {
+	// Trip straddles the 22:00 boundary: boarding is just before it,
+	// alighting just after.
+	boardTime := time.Date(2023, 1, 1, 21, 55, 0, 0, time.UTC)
+	alightTime := time.Date(2023, 1, 1, 22, 5, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: boardTime, Distance: decimal.NewFromInt(0)},
+		{Timestamp: alightTime, Distance: decimal.NewFromInt(500)}, // base fare only: 400 yen subtotal
+	}
+
+	t.Run("BasisBoarding: no surcharge when boarding precedes the window", func(t *testing.T) {
+		calc := &TaxiCalculator{
+			NightSurchargePercent:   decimal.NewFromInt(20),
+			NightSurchargeStartHour: 22,
+			NightSurchargeEndHour:   5,
+			SurchargeBasis:          BasisBoarding,
+		}
+		result := calc.CalculateFromRecords(records)
+		if !result.TotalFare.Equal(decimal.NewFromInt(400)) {
+			t.Errorf("Expected no surcharge with BasisBoarding, got TotalFare %s", result.TotalFare.String())
+		}
+	})
+
+	t.Run("BasisAlighting: surcharge applies when alighting falls within the window", func(t *testing.T) {
+		calc := &TaxiCalculator{
+			NightSurchargePercent:   decimal.NewFromInt(20),
+			NightSurchargeStartHour: 22,
+			NightSurchargeEndHour:   5,
+			SurchargeBasis:          BasisAlighting,
+		}
+		result := calc.CalculateFromRecords(records)
+		expectedTotal := decimal.NewFromInt(480) // 400 + 20%
+		if !result.TotalFare.Equal(expectedTotal) {
+			t.Errorf("Expected surcharge with BasisAlighting, got TotalFare %s, want %s", result.TotalFare.String(), expectedTotal.String())
+		}
+	})
+
+	t.Run("default SurchargeBasis is BasisBoarding", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		if calc.SurchargeBasis != BasisBoarding {
+			t.Errorf("Expected default SurchargeBasis to be BasisBoarding, got %s", calc.SurchargeBasis)
+		}
+	})
+}
+
+func TestTaxiCalculator_CalculateFromRecords_RoundingDelta(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A 500m trip (base fare only, 400 yen subtotal) with a 0.175% service
+	// charge (0.7 yen) lands the total at 400.7, a known ".7" fare.
+	calc := &TaxiCalculator{ServiceChargePercent: decimal.NewFromFloat(0.175)}
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12345500)},
+	}
+
+	result := calc.CalculateFromRecords(records)
+
+	expectedTotal := decimal.NewFromFloat(400.7)
+	if !result.TotalFare.Equal(expectedTotal) {
+		t.Fatalf("Expected TotalFare %s, got %s", expectedTotal.String(), result.TotalFare.String())
+	}
+
+	expectedDelta := decimal.NewFromFloat(0.3)
+	if !result.RoundingDelta.Equal(expectedDelta) {
+		t.Errorf("Expected RoundingDelta %s, got %s", expectedDelta.String(), result.RoundingDelta.String())
+	}
+}
+
+func TestPublishTable(t *testing.T) {
+	calc := NewCalculator()
+
+	table := PublishTable(calc, []decimal.Decimal{
+		decimal.NewFromInt(1000),
+		decimal.NewFromInt(2000),
+		decimal.NewFromInt(12000),
+	})
+
+	expected := [][2]string{
+		{"1000", "400"},
+		{"2000", "520"},
+		{"12000", "1560"},
+	}
+
+	if len(table) != len(expected) {
+		t.Fatalf("Expected %d rows, got %d", len(expected), len(table))
+	}
+
+	for i, row := range expected {
+		if table[i][0] != row[0] || table[i][1] != row[1] {
+			t.Errorf("Row %d: expected %v, got %v", i, row, table[i])
+		}
+	}
+}
+
+func TestFare(t *testing.T) {
+	got := Fare(decimal.NewFromInt(12000))
+	want := decimal.NewFromInt(1560)
+
+	if !got.Equal(want) {
+		t.Errorf("Fare(12000) = %s, want %s", got.String(), want.String())
+	}
+}
+
+func TestMarginalRatePerMeter(t *testing.T) {
+	tests := []struct {
+		name     string
+		atMeters decimal.Decimal
+		expected decimal.Decimal
+	}{
+		{"within base-fare region", decimal.NewFromInt(500), decimal.Zero},
+		{"negative distance treated as zero, base-fare region", decimal.NewFromInt(-100), decimal.Zero},
+		{"at the base/standard boundary", BaseDistance, StandardRate.Div(StandardUnit)},
+		{"within the standard band", decimal.NewFromInt(5000), StandardRate.Div(StandardUnit)},
+		{"at the standard/extended boundary", StandardThreshold, ExtendedRate.Div(ExtendedUnit)},
+		{"within the extended band", decimal.NewFromInt(15000), ExtendedRate.Div(ExtendedUnit)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MarginalRatePerMeter(tt.atMeters)
+			if !got.Equal(tt.expected) {
+				t.Errorf("MarginalRatePerMeter(%s) = %s, want %s", tt.atMeters.String(), got.String(), tt.expected.String())
+			}
+		})
+	}
+}
+
+func TestCompareToFlatRate(t *testing.T) {
+	calc := NewCalculator()
+
+	records := []models.DistanceRecord{
+		{Distance: decimal.Zero},
+		{Distance: decimal.NewFromInt(12000)},
+	}
+	flatRatePerKm := decimal.NewFromInt(100)
+
+	got := CompareToFlatRate(calc, records, flatRatePerKm)
+
+	wantDistance := decimal.NewFromInt(12000)
+	if !got.Distance.Equal(wantDistance) {
+		t.Errorf("Distance = %s, want %s", got.Distance.String(), wantDistance.String())
+	}
+
+	wantMetered := calc.CalculateFromRecords(records).TotalFare
+	if !got.MeteredFare.Equal(wantMetered) {
+		t.Errorf("MeteredFare = %s, want %s", got.MeteredFare.String(), wantMetered.String())
+	}
+
+	wantFlat := decimal.NewFromInt(1200) // 12km * 100 yen/km
+	if !got.FlatFare.Equal(wantFlat) {
+		t.Errorf("FlatFare = %s, want %s", got.FlatFare.String(), wantFlat.String())
+	}
+
+	wantDifference := wantMetered.Sub(wantFlat)
+	if !got.Difference.Equal(wantDifference) {
+		t.Errorf("Difference = %s, want %s", got.Difference.String(), wantDifference.String())
+	}
+}
+
+func TestCalculateTripFare(t *testing.T) {
+	calc := NewCalculator()
+
+	t.Run("matches CalculateFromRecords on equivalent inputs", func(t *testing.T) {
+		start := decimal.NewFromInt(12345000)
+		end := decimal.NewFromInt(12357000)
+
+		got, err := CalculateTripFare(calc, start, end)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := calc.CalculateFromRecords([]models.DistanceRecord{
+			{Distance: start},
+			{Distance: end},
+		})
+
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.TotalFare.String())
+		}
+		if !got.BaseFare.Equal(want.BaseFare) {
+			t.Errorf("BaseFare = %s, want %s", got.BaseFare.String(), want.BaseFare.String())
+		}
+		if !got.DistanceFare.Equal(want.DistanceFare) {
+			t.Errorf("DistanceFare = %s, want %s", got.DistanceFare.String(), want.DistanceFare.String())
+		}
+	})
+
+	t.Run("errors when endDistance is less than startDistance", func(t *testing.T) {
+		_, err := CalculateTripFare(calc, decimal.NewFromInt(2000), decimal.NewFromInt(1000))
+		if err == nil {
+			t.Fatal("Expected error for endDistance < startDistance, got nil")
+		}
+	})
+}
+
+func TestTaxiCalculator_EstimateFare(t *testing.T) {
+	calc := NewCalculator().(*TaxiCalculator)
+
+	t.Run("matches the records-based result for the same distance", func(t *testing.T) {
+		distance := decimal.NewFromInt(12000)
+
+		got := calc.EstimateFare(distance)
+
+		want := calc.CalculateFromRecords([]models.DistanceRecord{
+			{Distance: decimal.Zero},
+			{Distance: distance},
+		})
+
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.TotalFare.String())
+		}
+		if !got.BaseFare.Equal(want.BaseFare) {
+			t.Errorf("BaseFare = %s, want %s", got.BaseFare.String(), want.BaseFare.String())
+		}
+		if !got.DistanceFare.Equal(want.DistanceFare) {
+			t.Errorf("DistanceFare = %s, want %s", got.DistanceFare.String(), want.DistanceFare.String())
+		}
+	})
+
+	t.Run("within base distance charges only the base fare", func(t *testing.T) {
+		got := calc.EstimateFare(decimal.NewFromInt(500))
+
+		if !got.BaseFare.Equal(got.TotalFare) {
+			t.Errorf("Expected BaseFare to equal TotalFare within the base distance, got BaseFare=%s TotalFare=%s",
+				got.BaseFare.String(), got.TotalFare.String())
+		}
+		if !got.DistanceFare.IsZero() {
+			t.Errorf("Expected zero DistanceFare within the base distance, got %s", got.DistanceFare.String())
+		}
+	})
+}
+
+func TestTaxiCalculator_DistanceStrategy(t *testing.T) {
+	// A non-monotonic series where the three strategies all diverge:
+	//   StrategyMinMax:    max(5000) - min(0)              = 5000m
+	//   StrategyFirstLast: last(4000) - first(0)            = 4000m
+	//   StrategySumDeltas: 5000 + 3000 (ignoring the -4000 dip) = 8000m
+	records := []models.DistanceRecord{
+		{Distance: decimal.NewFromInt(0)},
+		{Distance: decimal.NewFromInt(5000)},
+		{Distance: decimal.NewFromInt(1000)},
+		{Distance: decimal.NewFromInt(4000)},
+	}
+
+	t.Run("zero value StrategyMinMax matches historical behavior", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+
+		got := calc.CalculateFromRecords(records)
+		want := calc.CalculateFare(decimal.NewFromInt(5000)) // max(5000) - min(0)
+
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.TotalFare.String())
+		}
+	})
+
+	t.Run("StrategyFirstLast ignores the mid-trip dip", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		calc.DistanceStrategy = StrategyFirstLast
+
+		got := calc.CalculateFromRecords(records)
+		want := calc.CalculateFare(decimal.NewFromInt(4000)) // last(4000) - first(0)
+
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.TotalFare.String())
+		}
+	})
+
+	t.Run("StrategySumDeltas accumulates only forward progress", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		calc.DistanceStrategy = StrategySumDeltas
+
+		got := calc.CalculateFromRecords(records)
+		want := calc.CalculateFare(decimal.NewFromInt(8000)) // 5000 + 3000, ignoring the -4000 dip
+
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.TotalFare.String())
+		}
+	})
+
+	t.Run("TripDistanceStrategy.String", func(t *testing.T) {
+		cases := map[TripDistanceStrategy]string{
+			StrategyMinMax:    "min-max",
+			StrategyFirstLast: "first-last",
+			StrategySumDeltas: "sum-deltas",
+		}
+		for strategy, want := range cases {
+			if got := strategy.String(); got != want {
+				t.Errorf("String() for %d = %q, want %q", strategy, got, want)
+			}
+		}
+	})
+}
+
+// TestTaxiCalculator_OdometerMax mirrors
+// datavalidator.TestDataValidator_OdometerMax: with OdometerMax set to the
+// same value a DataValidator would use to accept a rollover sequence,
+// CalculateFromRecords must bill the true travel distance across the
+// rollover, not the raw (and here hugely negative-then-positive) readings.
+func TestTaxiCalculator_OdometerMax(t *testing.T) {
+	// previous near OdometerMax, then a rollover, then ordinary travel;
+	// true total travel is (99800-99000) + (100000-99800+200) + (900-200) = 1900m.
+	records := []models.DistanceRecord{
+		{Distance: decimal.NewFromInt(99000)},
+		{Distance: decimal.NewFromInt(99800)},
+		{Distance: decimal.NewFromInt(200)},
+		{Distance: decimal.NewFromInt(900)},
+	}
+
+	t.Run("disabled by default: rollover reads as a huge negative delta", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		calc.DistanceStrategy = StrategyFirstLast
+
+		got := calc.CalculateFromRecords(records)
+		want := calc.CalculateFare(decimal.NewFromInt(900).Sub(decimal.NewFromInt(99000))) // last - first, negative
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("TotalFare = %s, want %s (historical behavior, ignoring rollover)", got.TotalFare, want.TotalFare)
+		}
+	})
+
+	for _, strategy := range []TripDistanceStrategy{StrategyMinMax, StrategyFirstLast, StrategySumDeltas} {
+		t.Run("OdometerMax set: "+strategy.String()+" bills the true rollover-aware distance", func(t *testing.T) {
+			calc := NewCalculator().(*TaxiCalculator)
+			calc.DistanceStrategy = strategy
+			calc.OdometerMax = decimal.NewFromInt(100000)
+
+			got := calc.CalculateFromRecords(records)
+			want := calc.CalculateFare(decimal.NewFromInt(1900))
+			if !got.TotalFare.Equal(want.TotalFare) {
+				t.Errorf("TotalFare = %s, want %s (1900m true travel distance)", got.TotalFare, want.TotalFare)
+			}
+		})
+	}
+}
+
+func TestTaxiCalculator_FareStrategy(t *testing.T) {
+	records := []models.DistanceRecord{
+		{Distance: decimal.NewFromInt(0)},
+		{Distance: decimal.NewFromInt(2500)},
+	}
+
+	t.Run("nil Strategy matches the historical metered CalculateFromRecords result", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+
+		got := calc.CalculateFromRecords(records)
+		want := calc.CalculateFare(decimal.NewFromInt(2500))
+
+		if !got.TotalFare.Equal(want.TotalFare) {
+			t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.TotalFare.String())
+		}
+	})
+
+	t.Run("FlatRateStrategy overrides the metered fare", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		calc.Strategy = FlatRateStrategy{RatePerKm: decimal.NewFromInt(100)}
+
+		got := calc.CalculateFromRecords(records)
+
+		// 2500m rounds up to 3km at 100 yen/km = 300 yen, rather than the
+		// metered base+standard fare.
+		want := decimal.NewFromInt(300)
+		if !got.TotalFare.Equal(want) {
+			t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.String())
+		}
+	})
+
+	t.Run("FlatRateStrategy.Fare rounds up to the next kilometer", func(t *testing.T) {
+		strategy := FlatRateStrategy{RatePerKm: decimal.NewFromInt(50)}
+
+		got := strategy.Fare(decimal.NewFromInt(1001))
+
+		if !got.TotalFare.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("TotalFare = %s, want 100", got.TotalFare.String())
+		}
+	})
+
+	t.Run("FlatRateStrategy.Fare charges nothing for a negative distance", func(t *testing.T) {
+		strategy := FlatRateStrategy{RatePerKm: decimal.NewFromInt(50)}
+
+		got := strategy.Fare(decimal.NewFromInt(-100))
+
+		if !got.TotalFare.IsZero() {
+			t.Errorf("TotalFare = %s, want 0", got.TotalFare.String())
+		}
+	})
+}
+
+func TestTaxiCalculator_ChargeBaseOnBoarding(t *testing.T) {
+	boardTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	alightTime := time.Date(2023, 1, 1, 12, 10, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: boardTime, Distance: decimal.NewFromInt(12345000)},
+		{Timestamp: alightTime, Distance: decimal.NewFromInt(12345000)},
+	}
+
+	t.Run("disabled by default: zero-distance trip charges nothing", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		result := calc.CalculateFromRecords(records)
+		if !result.TotalFare.IsZero() {
+			t.Errorf("Expected zero TotalFare by default, got %s", result.TotalFare.String())
+		}
+	})
+
+	t.Run("enabled: zero-distance, multi-record trip charges the base fare", func(t *testing.T) {
+		calc := &TaxiCalculator{ChargeBaseOnBoarding: true}
+		result := calc.CalculateFromRecords(records)
+		if !result.TotalFare.Equal(BaseFare) {
+			t.Errorf("Expected TotalFare %s, got %s", BaseFare.String(), result.TotalFare.String())
+		}
+		if !result.BaseFare.Equal(BaseFare) {
+			t.Errorf("Expected BaseFare %s, got %s", BaseFare.String(), result.BaseFare.String())
+		}
+	})
+
+	t.Run("enabled: single record (no duration) still charges nothing", func(t *testing.T) {
+		calc := &TaxiCalculator{ChargeBaseOnBoarding: true}
+		result := calc.CalculateFromRecords(records[:1])
+		if !result.TotalFare.IsZero() {
+			t.Errorf("Expected zero TotalFare for a single record, got %s", result.TotalFare.String())
+		}
+	})
+
+	t.Run("enabled: has no effect when distance is nonzero", func(t *testing.T) {
+		calc := &TaxiCalculator{ChargeBaseOnBoarding: true}
+		moved := []models.DistanceRecord{
+			{Timestamp: boardTime, Distance: decimal.NewFromInt(12345000)},
+			{Timestamp: alightTime, Distance: decimal.NewFromInt(12346000)},
+		}
+		result := calc.CalculateFromRecords(moved)
+		want := calc.CalculateFare(decimal.NewFromInt(1000)).TotalFare
+		if !result.TotalFare.Equal(want) {
+			t.Errorf("Expected TotalFare %s, got %s", want.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("enabled with ServiceFee: zero-distance trip still charges the flat fee", func(t *testing.T) {
+		calc := &TaxiCalculator{ChargeBaseOnBoarding: true, ServiceFee: decimal.NewFromInt(50)}
+		result := calc.CalculateFromRecords(records)
+		want := BaseFare.Add(decimal.NewFromInt(50))
+		if !result.TotalFare.Equal(want) {
+			t.Errorf("Expected TotalFare %s, got %s", want.String(), result.TotalFare.String())
+		}
+	})
+}
+
+func TestExplainDelta(t *testing.T) {
+	calc := NewCalculator()
+
+	t.Run("crossing from standard into extended band", func(t *testing.T) {
+		explanation := ExplainDelta(calc, decimal.NewFromInt(9500), decimal.NewFromInt(10500))
+
+		if !strings.Contains(explanation, "crossed from standard into extended band") {
+			t.Errorf("Expected explanation to mention the band crossing, got: %s", explanation)
+		}
+	})
+
+	t.Run("no band crossing", func(t *testing.T) {
+		explanation := ExplainDelta(calc, decimal.NewFromInt(2000), decimal.NewFromInt(3000))
+
+		if !strings.Contains(explanation, "no band crossing") {
+			t.Errorf("Expected explanation to report no band crossing, got: %s", explanation)
+		}
+	})
+}
+
+func TestTaxiCalculator_DistanceFareRounding(t *testing.T) {
+	distance := decimal.NewFromFloat(1500.7) // base 400 + 500.7m standard distance
+
+	t.Run("zero value matches exact ceiled-unit behavior", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		result := calc.CalculateFare(distance)
+
+		expected := decimal.NewFromInt(400).Add(decimal.NewFromInt(2 * 40)) // ceil(500.7/400) = 2 units
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("Expected exact ceiled-unit total %s, got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("rounds the combined distance fare to the nearest increment", func(t *testing.T) {
+		calc := &TaxiCalculator{DistanceFareRounding: decimal.NewFromInt(100)}
+		result := calc.CalculateFare(distance)
+
+		// Exact distance fare is 80 yen (2 units * 40); nearest 100 is 100.
+		expectedTotal := decimal.NewFromInt(400).Add(decimal.NewFromInt(100))
+		if !result.TotalFare.Equal(expectedTotal) {
+			t.Errorf("Expected increment-rounded total %s, got %s", expectedTotal.String(), result.TotalFare.String())
+		}
+
+		if !result.StandardFareAmount.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("Expected StandardFareAmount %s, got %s", decimal.NewFromInt(100).String(), result.StandardFareAmount.String())
+		}
+	})
+}
+
 func TestTaxiCalculator_CalculateFareBoundaryConditions(t *testing.T) {
 	calc := NewCalculator().(*TaxiCalculator)
-	
+
 	// Test exact boundary at 1km
 	result1km := calc.CalculateFare(decimal.NewFromInt(1000))
 	expected1km := decimal.NewFromInt(400)
 	if !result1km.TotalFare.Equal(expected1km) {
 		t.Errorf("At exactly 1km, expected %s, got %s", expected1km.String(), result1km.TotalFare.String())
 	}
-	
+
 	// Test just over 1km
 	result1001m := calc.CalculateFare(decimal.NewFromInt(1001))
 	expectedOver1km := decimal.NewFromInt(440) // 400 base + 40 for first 400m unit
 	if !result1001m.TotalFare.Equal(expectedOver1km) {
 		t.Errorf("At 1001m, expected %s, got %s", expectedOver1km.String(), result1001m.TotalFare.String())
 	}
-	
+
 	// Test exact boundary at 10km
 	result10km := calc.CalculateFare(decimal.NewFromInt(10000))
 	// Base: 400, Standard: 9000m = 23 units of 400m (rounded up) = 23 * 40 = 920
@@ -180,16 +846,134 @@ func TestTaxiCalculator_CalculateFareBoundaryConditions(t *testing.T) {
 	expectedUnits := decimal.NewFromFloat(9000.0).Div(decimal.NewFromInt(400)).Ceil()
 	expectedStandardAt10km := expectedUnits.Mul(decimal.NewFromInt(40))
 	expected10km := decimal.NewFromInt(400).Add(expectedStandardAt10km)
-	
+
 	if !result10km.TotalFare.Equal(expected10km) {
 		t.Errorf("At exactly 10km, expected %s, got %s", expected10km.String(), result10km.TotalFare.String())
 	}
 }
 
+func TestTaxiCalculator_MeterContinuesPastBase(t *testing.T) {
+	t.Run("resets at boundary (default)", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+
+		result := calc.CalculateFare(decimal.NewFromFloat(1000.5))
+		expected := decimal.NewFromInt(440) // 400 base + 40 for the immediately-rounded-up partial unit
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("At 1000.5m with reset meter, expected %s, got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("continues past boundary", func(t *testing.T) {
+		calc := NewCalculatorWithOptions(true).(*TaxiCalculator)
+
+		result := calc.CalculateFare(decimal.NewFromFloat(1000.5))
+		expected := decimal.NewFromInt(400) // base only; less than a full 400m unit travelled since the boundary
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("At 1000.5m with continuing meter, expected %s, got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("full unit charged identically under both settings", func(t *testing.T) {
+		for _, continues := range []bool{false, true} {
+			calc := NewCalculatorWithOptions(continues).(*TaxiCalculator)
+			result := calc.CalculateFare(decimal.NewFromInt(1400)) // exactly one full standard unit past base
+			expected := decimal.NewFromInt(440)
+			if !result.TotalFare.Equal(expected) {
+				t.Errorf("At 1400m (MeterContinuesPastBase=%t), expected %s, got %s",
+					continues, expected.String(), result.TotalFare.String())
+			}
+		}
+	})
+}
+
+func TestTaxiCalculator_StandardUnitRounding(t *testing.T) {
+	// At exactly 10000m, the standard tier spans 9000m = 22.5 units of 400m.
+	tests := []struct {
+		name     string
+		rounding UnitRoundingMode
+		expected decimal.Decimal
+	}{
+		{
+			name:     "RoundUnitsUp rounds 22.5 units up to 23",
+			rounding: RoundUnitsUp,
+			expected: decimal.NewFromInt(400).Add(decimal.NewFromInt(23 * 40)),
+		},
+		{
+			name:     "RoundUnitsHalfEven rounds 22.5 units to 22 (nearest even)",
+			rounding: RoundUnitsHalfEven,
+			expected: decimal.NewFromInt(400).Add(decimal.NewFromInt(22 * 40)),
+		},
+		{
+			name:     "RoundUnitsDown truncates 22.5 units to 22",
+			rounding: RoundUnitsDown,
+			expected: decimal.NewFromInt(400).Add(decimal.NewFromInt(22 * 40)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := &TaxiCalculator{StandardUnitRounding: tt.rounding}
+
+			result := calc.CalculateFare(decimal.NewFromInt(10000))
+			if !result.TotalFare.Equal(tt.expected) {
+				t.Errorf("At exactly 10000m with %v, expected %s, got %s",
+					tt.rounding, tt.expected.String(), result.TotalFare.String())
+			}
+		})
+	}
+
+	t.Run("default zero value matches historical RoundUnitsUp behavior", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		result := calc.CalculateFare(decimal.NewFromInt(10000))
+		expected := decimal.NewFromInt(400).Add(decimal.NewFromInt(23 * 40))
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("At exactly 10000m, expected %s, got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+}
+
+func TestTaxiCalculator_PartialUnitGraceMeters(t *testing.T) {
+	calc := &TaxiCalculator{PartialUnitGraceMeters: decimal.NewFromInt(50)}
+
+	t.Run("1005m is within grace: no standard-rate unit charged", func(t *testing.T) {
+		result := calc.CalculateFare(decimal.NewFromInt(1005))
+		expected := BaseFare
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("At 1005m, expected %s (base fare only), got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("1100m is beyond grace: full standard-rate unit charged", func(t *testing.T) {
+		result := calc.CalculateFare(decimal.NewFromInt(1100))
+		expected := BaseFare.Add(StandardRate)
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("At 1100m, expected %s, got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("zero value disables grace, matching historical round-up behavior", func(t *testing.T) {
+		calc := NewCalculator().(*TaxiCalculator)
+		result := calc.CalculateFare(decimal.NewFromInt(1005))
+		expected := BaseFare.Add(StandardRate)
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("At 1005m with no grace configured, expected %s, got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("has no effect when MeterContinuesPastBase already floors partial units", func(t *testing.T) {
+		calc := &TaxiCalculator{MeterContinuesPastBase: true, PartialUnitGraceMeters: decimal.NewFromInt(5)}
+		result := calc.CalculateFare(decimal.NewFromInt(1100))
+		expected := BaseFare
+		if !result.TotalFare.Equal(expected) {
+			t.Errorf("At 1100m with MeterContinuesPastBase, expected %s, got %s", expected.String(), result.TotalFare.String())
+		}
+	})
+}
+
 func TestTaxiCalculator_CalculateFromRecords(t *testing.T) {
 	calc := NewCalculator().(*TaxiCalculator)
 	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
-	
+
 	tests := []struct {
 		name     string
 		records  []models.DistanceRecord
@@ -258,23 +1042,23 @@ func TestTaxiCalculator_CalculateFromRecords(t *testing.T) {
 			},
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := calc.CalculateFromRecords(tt.records)
-			
+
 			if !result.BaseFare.Equal(tt.expected.BaseFare) {
 				t.Errorf("Expected base fare %s, got %s", tt.expected.BaseFare.String(), result.BaseFare.String())
 			}
-			
+
 			if !result.DistanceFare.Equal(tt.expected.DistanceFare) {
 				t.Errorf("Expected distance fare %s, got %s", tt.expected.DistanceFare.String(), result.DistanceFare.String())
 			}
-			
+
 			if !result.TimeFare.Equal(tt.expected.TimeFare) {
 				t.Errorf("Expected time fare %s, got %s", tt.expected.TimeFare.String(), result.TimeFare.String())
 			}
-			
+
 			if !result.TotalFare.Equal(tt.expected.TotalFare) {
 				t.Errorf("Expected total fare %s, got %s", tt.expected.TotalFare.String(), result.TotalFare.String())
 			}
@@ -282,6 +1066,51 @@ func TestTaxiCalculator_CalculateFromRecords(t *testing.T) {
 	}
 }
 
+func TestTaxiCalculator_CalculateFromRecordsFastPath(t *testing.T) {
+	calc := NewCalculator().(*TaxiCalculator)
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("zero distance stays zero fare", func(t *testing.T) {
+		// Mirrors CalculateFare's own zero-distance handling: no travel means
+		// no fare, whether or not the fast path is taken.
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12345000)},
+		}
+
+		result := calc.CalculateFromRecords(records)
+		if !result.TotalFare.Equal(decimal.Zero) {
+			t.Errorf("Expected total fare 0, got %s", result.TotalFare.String())
+		}
+	})
+
+	t.Run("decreasing distance falls back to min/max scan", func(t *testing.T) {
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(12357000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12345000)}, // odometer decreased
+		}
+
+		result := calc.CalculateFromRecords(records)
+		expected := calc.CalculateFare(decimal.NewFromInt(12000)) // max - min = 12000m
+		if !result.TotalFare.Equal(expected.TotalFare) {
+			t.Errorf("Expected total fare %s, got %s", expected.TotalFare.String(), result.TotalFare.String())
+		}
+	})
+
+	t.Run("fast path matches full scan for short trips", func(t *testing.T) {
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+			{Timestamp: baseTime.Add(30 * time.Second), Distance: decimal.NewFromInt(12345200)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12345900)},
+		}
+
+		result := calc.CalculateFromRecords(records)
+		if !result.TotalFare.Equal(decimal.NewFromInt(400)) {
+			t.Errorf("Expected total fare 400, got %s", result.TotalFare.String())
+		}
+	})
+}
+
 func TestFareBreakdown_String(t *testing.T) {
 	breakdown := FareBreakdown{
 		Distance:           decimal.NewFromFloat(1500.0),
@@ -290,12 +1119,12 @@ func TestFareBreakdown_String(t *testing.T) {
 		ExtendedFareAmount: decimal.Zero,
 		TotalFare:          decimal.NewFromInt(480),
 	}
-	
+
 	str := breakdown.String()
 	if str == "" {
 		t.Error("String representation should not be empty")
 	}
-	
+
 	// Check that all components are included in the string
 	if !containsString(str, "1500.0") || !containsString(str, "400") || !containsString(str, "80") || !containsString(str, "480") {
 		t.Errorf("String representation missing components: %s", str)
@@ -318,36 +1147,61 @@ func containsSubstring(s, substr string) bool {
 
 func TestFareMonotonicity(t *testing.T) {
 	calc := NewCalculator().(*TaxiCalculator)
-	
+
 	// Property-based test: fare should never decrease as distance increases
 	distances := []int{0, 500, 1000, 1500, 2000, 5000, 10000, 12000, 15000, 20000}
-	
+
 	var prevFare decimal.Decimal
 	for i, dist := range distances {
 		result := calc.CalculateFare(decimal.NewFromInt(int64(dist)))
-		
+
 		if i > 0 && result.TotalFare.LessThan(prevFare) {
 			t.Errorf("Fare monotonicity violated: distance %dm has fare %s, but previous distance had fare %s",
 				dist, result.TotalFare.String(), prevFare.String())
 		}
-		
+
 		prevFare = result.TotalFare
 	}
 }
 
 func TestDecimalPrecision(t *testing.T) {
 	calc := NewCalculator().(*TaxiCalculator)
-	
+
 	// Test with fractional meters to ensure decimal precision is maintained
 	result := calc.CalculateFare(decimal.NewFromFloat(1500.7))
-	
+
 	if result.Distance.IsZero() {
 		t.Error("Distance should be preserved with decimal precision")
 	}
-	
+
 	// Ensure calculations are still accurate with decimal inputs
 	expected := decimal.NewFromInt(480) // 400 base + 80 standard
 	if !result.TotalFare.Equal(expected) {
 		t.Errorf("Expected %s for 1500.7m, got %s", expected.String(), result.TotalFare.String())
 	}
-}
\ No newline at end of file
+}
+
+// benchmarkRecords builds a large low-distance dataset (total travel under
+// BaseDistance) to exercise the CalculateFromRecords fast path.
+func benchmarkRecords(n int) []models.DistanceRecord {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := make([]models.DistanceRecord, n)
+	start := decimal.NewFromInt(12345000)
+	for i := 0; i < n; i++ {
+		records[i] = models.DistanceRecord{
+			Timestamp: baseTime.Add(time.Duration(i) * time.Second),
+			Distance:  start.Add(decimal.NewFromFloat(float64(i) * 0.5)), // 500m total over n records
+		}
+	}
+	return records
+}
+
+func BenchmarkCalculateFromRecords_ShortTrip(b *testing.B) {
+	calc := NewCalculator().(*TaxiCalculator)
+	records := benchmarkRecords(10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		calc.CalculateFromRecords(records)
+	}
+}
@@ -0,0 +1,109 @@
+package farecalculator
+
+import (
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// FareAccumulator computes the fare calc.CalculateFromRecords would give a
+// non-decreasing sequence of records (see Result for other sequences), but
+// without buffering the sequence: it retains
+// only the first and last record added (for the night-surcharge basis
+// timestamp) and the running minimum/maximum distance seen so far, so memory
+// use is O(1) regardless of how many records are added. This supports
+// StreamingMode (see main.Application), where holding every record in memory
+// isn't acceptable for a truly unbounded stream.
+type FareAccumulator struct {
+	calc Calculator
+	tc   *TaxiCalculator // non-nil when calc is a *TaxiCalculator with OdometerMax set
+
+	count                    int
+	first, last              models.DistanceRecord
+	minDistance, maxDistance decimal.Decimal
+
+	// previousRaw and cumulative track tc.unrollOdometer's running total one
+	// record at a time, so minDistance/maxDistance are computed over the
+	// unrolled sequence instead of raw, possibly rollover-wrapped readings.
+	// Only used when tc is non-nil.
+	previousRaw models.DistanceRecord
+	cumulative  decimal.Decimal
+}
+
+// NewFareAccumulator creates a FareAccumulator that will compute fares using
+// calc's fare table.
+func NewFareAccumulator(calc Calculator) *FareAccumulator {
+	fa := &FareAccumulator{calc: calc}
+	if tc, ok := calc.(*TaxiCalculator); ok && !tc.OdometerMax.IsZero() {
+		fa.tc = tc
+	}
+	return fa
+}
+
+// Add folds record into the running aggregate in O(1) time and space. When
+// calc is a *TaxiCalculator with OdometerMax set, record's distance is first
+// unrolled against the previous record exactly as tc.unrollOdometer would,
+// so a rollover crossed mid-stream doesn't register as a huge decrease (see
+// Result).
+func (fa *FareAccumulator) Add(record models.DistanceRecord) {
+	distance := record.Distance
+	if fa.tc != nil {
+		if fa.count == 0 {
+			fa.cumulative = record.Distance
+		} else {
+			delta := record.Distance.Sub(fa.previousRaw.Distance)
+			if delta.IsNegative() && fa.tc.isOdometerRollover(fa.previousRaw, record) {
+				delta = fa.tc.OdometerMax.Sub(fa.previousRaw.Distance).Add(record.Distance)
+			}
+			fa.cumulative = fa.cumulative.Add(delta)
+		}
+		fa.previousRaw = record
+		distance = fa.cumulative
+	}
+
+	if fa.count == 0 {
+		fa.first = record
+		fa.minDistance = distance
+		fa.maxDistance = distance
+	} else {
+		if distance.LessThan(fa.minDistance) {
+			fa.minDistance = distance
+		}
+		if distance.GreaterThan(fa.maxDistance) {
+			fa.maxDistance = distance
+		}
+	}
+	fa.last = record
+	fa.count++
+}
+
+// Count returns the number of records added so far.
+func (fa *FareAccumulator) Count() int {
+	return fa.count
+}
+
+// Result computes the fare for all records added so far. It reduces the
+// accumulated state to a two-element {min-distance, max-distance} record
+// pair and prices that with calc.CalculateFromRecords, so the fare always
+// covers the min/max spread. For a non-decreasing sequence the result is
+// identical to running calc.CalculateFromRecords on the buffered sequence —
+// including under OdometerMax, since Add already folded any rollover into
+// minDistance/maxDistance before they reach here, and a min/max pair built
+// from an already-unrolled, non-decreasing pair is a no-op for
+// CalculateFromRecords's own unrollOdometer pass. For a sequence whose
+// distance dips it can differ: StrategyFirstLast, StrategySumDeltas and
+// CalculateFromRecords's first-to-last fast path do not price the min/max
+// spread of the buffered sequence. The
+// boarding/alighting timestamps used for SurchargeBasis are taken from the
+// first and last records actually added, preserving that check regardless
+// of where the min/max distance occurred in the sequence.
+func (fa *FareAccumulator) Result() models.FareCalculation {
+	if fa.count == 0 {
+		return fa.calc.CalculateFromRecords(nil)
+	}
+
+	return fa.calc.CalculateFromRecords([]models.DistanceRecord{
+		{Timestamp: fa.first.Timestamp, Distance: fa.minDistance},
+		{Timestamp: fa.last.Timestamp, Distance: fa.maxDistance},
+	})
+}
@@ -0,0 +1,78 @@
+package farecalculator
+
+import (
+	"testing"
+	"time"
+
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFareAccumulator_MatchesCalculateFromRecords(t *testing.T) {
+	calc := NewCalculator()
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12346000)},
+		{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(12347000)},
+		{Timestamp: baseTime.Add(3 * time.Minute), Distance: decimal.NewFromInt(12357000)},
+	}
+
+	want := calc.CalculateFromRecords(records)
+
+	acc := NewFareAccumulator(calc)
+	for _, record := range records {
+		acc.Add(record)
+	}
+	got := acc.Result()
+
+	if !got.TotalFare.Equal(want.TotalFare) {
+		t.Errorf("TotalFare = %s, want %s", got.TotalFare.String(), want.TotalFare.String())
+	}
+	if !got.BaseFare.Equal(want.BaseFare) {
+		t.Errorf("BaseFare = %s, want %s", got.BaseFare.String(), want.BaseFare.String())
+	}
+	if !got.DistanceFare.Equal(want.DistanceFare) {
+		t.Errorf("DistanceFare = %s, want %s", got.DistanceFare.String(), want.DistanceFare.String())
+	}
+	if acc.Count() != len(records) {
+		t.Errorf("Count() = %d, want %d", acc.Count(), len(records))
+	}
+}
+
+func TestFareAccumulator_MatchesCalculateFromRecords_OdometerMax(t *testing.T) {
+	calc := &TaxiCalculator{OdometerMax: decimal.NewFromInt(100000)}
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(95000)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(3000)},
+	}
+
+	want := calc.CalculateFromRecords(records)
+
+	acc := NewFareAccumulator(calc)
+	for _, record := range records {
+		acc.Add(record)
+	}
+	got := acc.Result()
+
+	if !got.TotalFare.Equal(want.TotalFare) {
+		t.Errorf("TotalFare = %s, want %s (streamed result must match buffered, rollover-aware result)", got.TotalFare.String(), want.TotalFare.String())
+	}
+}
+
+func TestFareAccumulator_EmptyAndSingleRecord(t *testing.T) {
+	calc := NewCalculator()
+
+	empty := NewFareAccumulator(calc)
+	if got := empty.Result().TotalFare; !got.IsZero() {
+		t.Errorf("empty accumulator TotalFare = %s, want 0", got.String())
+	}
+
+	single := NewFareAccumulator(calc)
+	single.Add(models.DistanceRecord{Timestamp: time.Now(), Distance: decimal.NewFromInt(12345678)})
+	if got := single.Result().TotalFare; !got.IsZero() {
+		t.Errorf("single-record accumulator TotalFare = %s, want 0 (matches CalculateFromRecords single-record behavior)", got.String())
+	}
+}
@@ -0,0 +1,58 @@
+package farecalculator
+
+import (
+	"time"
+
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// FareEvent represents the cumulative fare at a single point along a trip,
+// for live meter displays that need to show the fare ticking upward over
+// time rather than a single end-of-trip total.
+type FareEvent struct {
+	// Timestamp is the record's timestamp this event corresponds to.
+	Timestamp time.Time `json:"timestamp"`
+
+	// CumulativeFare is the fare accrued from the start of the trip up to
+	// and including this record.
+	CumulativeFare decimal.Decimal `json:"cumulative_fare"`
+}
+
+// CalculateFareEvents returns one FareEvent per record in records, using
+// calc's fare table, separating the boarding event (the base fare, charged
+// at the first record before any distance has accrued) from the fare
+// accrued at each subsequent record, priced as calc.CalculateFare of the
+// distance travelled since the first record. A record with no distance
+// travelled yet therefore carries calc.CalculateFare(0), which is below the
+// boarding event's BaseFare; from the first record with positive travel
+// onward, events are non-decreasing while mileage does not decrease. For a
+// non-decreasing sequence with positive travel, the last event's
+// CumulativeFare equals
+// calc.CalculateFromRecords(records).TotalFare, as long as calc has no
+// NightSurchargePercent configured — the night surcharge is computed once
+// over the whole trip by CalculateFromRecords, so it has no well-defined
+// per-event point to apply within this running sequence and is not
+// reflected here. Returns nil for an empty records slice.
+func CalculateFareEvents(calc Calculator, records []models.DistanceRecord) []FareEvent {
+	if len(records) == 0 {
+		return nil
+	}
+
+	events := make([]FareEvent, len(records))
+	events[0] = FareEvent{
+		Timestamp:      records[0].Timestamp,
+		CumulativeFare: BaseFare,
+	}
+
+	for i := 1; i < len(records); i++ {
+		traveled := records[i].Distance.Sub(records[0].Distance)
+		events[i] = FareEvent{
+			Timestamp:      records[i].Timestamp,
+			CumulativeFare: calc.CalculateFare(traveled).TotalFare,
+		}
+	}
+
+	return events
+}
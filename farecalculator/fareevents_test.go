@@ -0,0 +1,66 @@
+package farecalculator
+
+import (
+	"testing"
+	"time"
+
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateFareEvents_MonotonicAndMatchesTotal(t *testing.T) {
+	calc := NewCalculator()
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(12346000)},
+		{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(12347000)},
+		{Timestamp: baseTime.Add(3 * time.Minute), Distance: decimal.NewFromInt(12357000)},
+	}
+
+	events := CalculateFareEvents(calc, records)
+	if len(events) != len(records) {
+		t.Fatalf("got %d events, want %d", len(events), len(records))
+	}
+
+	for i, event := range events {
+		if !event.Timestamp.Equal(records[i].Timestamp) {
+			t.Errorf("event[%d].Timestamp = %v, want %v", i, event.Timestamp, records[i].Timestamp)
+		}
+		if i > 0 && event.CumulativeFare.LessThan(events[i-1].CumulativeFare) {
+			t.Errorf("event[%d].CumulativeFare = %s, less than event[%d] = %s (not monotonic)",
+				i, event.CumulativeFare.String(), i-1, events[i-1].CumulativeFare.String())
+		}
+	}
+
+	total := calc.CalculateFromRecords(records)
+	last := events[len(events)-1]
+	if !last.CumulativeFare.Equal(total.TotalFare) {
+		t.Errorf("last event CumulativeFare = %s, want %s (CalculateFromRecords total)",
+			last.CumulativeFare.String(), total.TotalFare.String())
+	}
+}
+
+func TestCalculateFareEvents_FirstEventIsBoardingFare(t *testing.T) {
+	calc := NewCalculator()
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(5000)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(5500)},
+	}
+
+	events := CalculateFareEvents(calc, records)
+	if !events[0].CumulativeFare.Equal(BaseFare) {
+		t.Errorf("first event CumulativeFare = %s, want boarding base fare %s",
+			events[0].CumulativeFare.String(), BaseFare.String())
+	}
+}
+
+func TestCalculateFareEvents_EmptyRecords(t *testing.T) {
+	calc := NewCalculator()
+	events := CalculateFareEvents(calc, nil)
+	if events != nil {
+		t.Errorf("expected nil events for empty records, got %v", events)
+	}
+}
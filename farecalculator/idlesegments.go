@@ -0,0 +1,75 @@
+package farecalculator
+
+import (
+	"time"
+
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// IdleSegment describes one contiguous stretch of a trip where the implied
+// speed between consecutive records stayed at or below a threshold, for
+// standalone reporting on idle-heavy trips (e.g. time stuck in traffic).
+type IdleSegment struct {
+	// StartIndex and EndIndex are the indices into the records slice passed
+	// to IdleSegments that bound this idle stretch (inclusive).
+	StartIndex int
+	EndIndex   int
+
+	// Duration is the time elapsed between records[StartIndex] and
+	// records[EndIndex].
+	Duration time.Duration
+
+	// ChargedAmount is the portion of TimeFare attributable to this idle
+	// segment. This calculator has no time-based fare component yet (see
+	// models.FareCalculation.TimeFare, always zero in CalculateFromRecords),
+	// so ChargedAmount is always zero until one is added.
+	ChargedAmount decimal.Decimal
+}
+
+// IdleSegments scans records pairwise, the same way
+// DataValidator.validateSpeedOutliers computes implied per-segment speed,
+// and reports each contiguous stretch where that speed is at or below
+// speedThreshold (meters per second) as a single IdleSegment, merging
+// adjacent below-threshold segments rather than reporting one per pair.
+// Requires at least two records; fewer returns nil.
+func IdleSegments(records []models.DistanceRecord, speedThreshold decimal.Decimal) []IdleSegment {
+	if len(records) < 2 {
+		return nil
+	}
+
+	var segments []IdleSegment
+	inIdleStretch := false
+
+	for i := 1; i < len(records); i++ {
+		prev, curr := records[i-1], records[i]
+		distDiff := curr.Distance.Sub(prev.Distance).Abs()
+		timeDiff := curr.Timestamp.Sub(prev.Timestamp)
+		if timeDiff <= 0 {
+			inIdleStretch = false
+			continue
+		}
+
+		speed := distDiff.Div(decimal.NewFromFloat(timeDiff.Seconds()))
+		if speed.GreaterThan(speedThreshold) {
+			inIdleStretch = false
+			continue
+		}
+
+		if inIdleStretch {
+			last := &segments[len(segments)-1]
+			last.EndIndex = i
+			last.Duration = curr.Timestamp.Sub(records[last.StartIndex].Timestamp)
+		} else {
+			segments = append(segments, IdleSegment{
+				StartIndex: i - 1,
+				EndIndex:   i,
+				Duration:   timeDiff,
+			})
+			inIdleStretch = true
+		}
+	}
+
+	return segments
+}
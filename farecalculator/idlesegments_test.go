@@ -0,0 +1,57 @@
+package farecalculator
+
+import (
+	"testing"
+	"time"
+
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestIdleSegments_TwoIdleStretches(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	threshold := decimal.NewFromFloat(0.5) // m/s
+
+	// Records 0-2: idle (barely moving). Records 2-3: moving normally.
+	// Records 3-5: idle again.
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+		{Timestamp: baseTime.Add(10 * time.Second), Distance: decimal.NewFromInt(1002)},
+		{Timestamp: baseTime.Add(20 * time.Second), Distance: decimal.NewFromInt(1004)},
+		{Timestamp: baseTime.Add(30 * time.Second), Distance: decimal.NewFromInt(1100)},
+		{Timestamp: baseTime.Add(40 * time.Second), Distance: decimal.NewFromInt(1102)},
+		{Timestamp: baseTime.Add(50 * time.Second), Distance: decimal.NewFromInt(1104)},
+	}
+
+	segments := IdleSegments(records, threshold)
+	if len(segments) != 2 {
+		t.Fatalf("got %d idle segments, want 2: %+v", len(segments), segments)
+	}
+
+	first := segments[0]
+	if first.StartIndex != 0 || first.EndIndex != 2 {
+		t.Errorf("first segment indices = [%d,%d], want [0,2]", first.StartIndex, first.EndIndex)
+	}
+	if first.Duration != 20*time.Second {
+		t.Errorf("first segment duration = %v, want 20s", first.Duration)
+	}
+
+	second := segments[1]
+	if second.StartIndex != 3 || second.EndIndex != 5 {
+		t.Errorf("second segment indices = [%d,%d], want [3,5]", second.StartIndex, second.EndIndex)
+	}
+	if second.Duration != 20*time.Second {
+		t.Errorf("second segment duration = %v, want 20s", second.Duration)
+	}
+}
+
+func TestIdleSegments_NoIdleBelowMinRecords(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+	}
+	if segments := IdleSegments(records, decimal.NewFromFloat(0.5)); segments != nil {
+		t.Errorf("expected nil for fewer than 2 records, got %+v", segments)
+	}
+}
@@ -182,7 +182,7 @@ func TestValidateDistanceFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateDistanceFormat(tt.input)
+			err := validateDistanceFormat(tt.input, false)
 			
 			if tt.wantErr {
 				if err == nil {
@@ -232,7 +232,7 @@ func TestParseDistanceWithValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseDistanceWithValidation(tt.input)
+			result, err := parseDistanceWithValidation(tt.input, false)
 			
 			if tt.wantErr {
 				if err == nil {
@@ -0,0 +1,143 @@
+package inputparser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"golang-taxi-fare/models"
+)
+
+// jsonRecord is the wire format for a single array element accepted by JSONParser
+type jsonRecord struct {
+	Timestamp string `json:"timestamp"`
+	Distance  string `json:"distance"`
+}
+
+// JSONParser implements the Parser interface for JSON-array formatted input:
+// `[{"timestamp":"hh:mm:ss.fff","distance":"xxxxxxxx.f"}, ...]`. It decodes
+// the array incrementally via json.Decoder.Token/Decode so memory use
+// doesn't grow with input size, and reuses the same timestamp/distance
+// validation as StreamParser. ParseResult.Line reports the zero-based
+// element index rather than a line number.
+type JSONParser struct{}
+
+// NewJSONParser creates a new JSONParser instance
+func NewJSONParser() Parser {
+	return &JSONParser{}
+}
+
+// ParseLine parses a single JSON object in the form
+// `{"timestamp":"hh:mm:ss.fff","distance":"xxxxxxxx.f"}`
+func (jp *JSONParser) ParseLine(line string) (models.DistanceRecord, error) {
+	var rec jsonRecord
+	if err := json.Unmarshal([]byte(line), &rec); err != nil {
+		return models.DistanceRecord{}, &ParsingError{
+			Type:    ErrorTypeFormat,
+			Message: fmt.Sprintf("invalid JSON element: %v", err),
+			Input:   line,
+		}
+	}
+	return parseJSONRecord(rec, 0)
+}
+
+// parseJSONRecord validates and converts a decoded jsonRecord into a
+// DistanceRecord, stamping any resulting ParsingError with the element index
+func parseJSONRecord(rec jsonRecord, index int) (models.DistanceRecord, error) {
+	timestamp, err := parseTimestampWithValidation(rec.Timestamp)
+	if err != nil {
+		if pe, ok := err.(*ParsingError); ok {
+			pe.Line = index
+		}
+		return models.DistanceRecord{}, err
+	}
+
+	distance, err := parseDistanceWithValidation(rec.Distance, false)
+	if err != nil {
+		if pe, ok := err.(*ParsingError); ok {
+			pe.Line = index
+		}
+		return models.DistanceRecord{}, err
+	}
+
+	return models.DistanceRecord{
+		Timestamp: timestamp,
+		Distance:  distance,
+	}, nil
+}
+
+// ParseStream implements streaming parsing of a top-level JSON array with context support
+func (jp *JSONParser) ParseStream(ctx context.Context, reader io.Reader) (<-chan ParseResult, error) {
+	resultChan := make(chan ParseResult, 10) // Buffered channel for better performance
+
+	decoder := json.NewDecoder(reader)
+
+	go func() {
+		defer close(resultChan)
+
+		token, err := decoder.Token()
+		if err != nil {
+			resultChan <- ParseResult{
+				Error: &ParsingError{
+					Type:    ErrorTypeIO,
+					Message: fmt.Sprintf("failed to read JSON array start: %v", err),
+				},
+			}
+			return
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			resultChan <- ParseResult{
+				Error: &ParsingError{
+					Type:    ErrorTypeFormat,
+					Message: "expected a top-level JSON array",
+				},
+			}
+			return
+		}
+
+		index := 0
+		for decoder.More() {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				resultChan <- ParseResult{Error: ctx.Err(), Line: index}
+				return
+			default:
+				// Continue processing
+			}
+
+			var rec jsonRecord
+			if err := decoder.Decode(&rec); err != nil {
+				// The decoder's position is unreliable after a syntax error,
+				// so report it and stop, mirroring the scanner-error handling
+				// in StreamParser.ParseStream
+				select {
+				case resultChan <- ParseResult{
+					Error: &ParsingError{
+						Type:    ErrorTypeFormat,
+						Message: fmt.Sprintf("malformed JSON element: %v", err),
+						Line:    index,
+					},
+					Line: index,
+				}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			record, err := parseJSONRecord(rec, index)
+
+			select {
+			case resultChan <- ParseResult{Record: record, Error: err, Line: index}:
+				// Successfully sent
+			case <-ctx.Done():
+				return
+			}
+
+			index++
+		}
+	}()
+
+	return resultChan, nil
+}
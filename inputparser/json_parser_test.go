@@ -0,0 +1,142 @@
+package inputparser
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewJSONParser(t *testing.T) {
+	parser := NewJSONParser()
+	if parser == nil {
+		t.Error("Expected non-nil parser")
+	}
+
+	if _, ok := parser.(Parser); !ok {
+		t.Error("JSONParser should implement Parser interface")
+	}
+}
+
+func TestJSONParser_ParseStream_ValidArray(t *testing.T) {
+	input := `[
+		{"timestamp":"12:34:56.789","distance":"12345678.5"},
+		{"timestamp":"00:00:00.000","distance":"87654321.123"},
+		{"timestamp":"23:59:59.999","distance":"99999999.9"}
+	]`
+
+	parser := NewJSONParser()
+	ctx := context.Background()
+	reader := strings.NewReader(input)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	var results []ParseResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("ParseStream() got %d results, want 3", len(results))
+	}
+
+	for i, result := range results {
+		if result.Error != nil {
+			t.Errorf("ParseStream() result[%d] unexpected error: %v", i, result.Error)
+		}
+		if result.Line != i {
+			t.Errorf("ParseStream() result[%d] element index = %d, want %d", i, result.Line, i)
+		}
+	}
+
+	expectedTime := mustParseTime("12:34:56.789")
+	if !results[0].Record.Timestamp.Equal(expectedTime) {
+		t.Errorf("ParseStream() result[0] timestamp = %v, want %v", results[0].Record.Timestamp, expectedTime)
+	}
+
+	expectedDistance := mustDecimal("87654321.123")
+	if !results[1].Record.Distance.Equal(expectedDistance) {
+		t.Errorf("ParseStream() result[1] distance = %v, want %v", results[1].Record.Distance, expectedDistance)
+	}
+}
+
+func TestJSONParser_ParseStream_MalformedElementMidArray(t *testing.T) {
+	input := `[
+		{"timestamp":"12:34:56.789","distance":"12345678.5"},
+		{"timestamp":"12:34:56.789","distance":"not-a-distance"},
+		{"timestamp":"23:59:59.999","distance":"99999999.9"}
+	]`
+
+	parser := NewJSONParser()
+	ctx := context.Background()
+	reader := strings.NewReader(input)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	var results []ParseResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("ParseStream() got %d results, want 3 (malformed element should still report a result)", len(results))
+	}
+
+	if results[0].Error != nil {
+		t.Errorf("ParseStream() result[0] unexpected error: %v", results[0].Error)
+	}
+
+	if results[1].Error == nil {
+		t.Error("ParseStream() expected an error for the malformed element")
+	}
+	if results[1].Line != 1 {
+		t.Errorf("ParseStream() malformed element index = %d, want 1", results[1].Line)
+	}
+
+	if results[2].Error != nil {
+		t.Errorf("ParseStream() result[2] unexpected error: %v", results[2].Error)
+	}
+}
+
+func TestJSONParser_ParseStream_NotAnArray(t *testing.T) {
+	parser := NewJSONParser()
+	ctx := context.Background()
+	reader := strings.NewReader(`{"timestamp":"12:34:56.789","distance":"12345678.5"}`)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	var results []ParseResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+
+	if len(results) != 1 || results[0].Error == nil {
+		t.Fatalf("ParseStream() expected a single error result for non-array input, got %+v", results)
+	}
+}
+
+func TestJSONParser_ParseLine(t *testing.T) {
+	parser := NewJSONParser()
+
+	record, err := parser.ParseLine(`{"timestamp":"12:34:56.789","distance":"12345678.5"}`)
+	if err != nil {
+		t.Fatalf("ParseLine() unexpected error = %v", err)
+	}
+
+	expectedTime := mustParseTime("12:34:56.789")
+	if !record.Timestamp.Equal(expectedTime) {
+		t.Errorf("ParseLine() timestamp = %v, want %v", record.Timestamp, expectedTime)
+	}
+
+	if _, err := parser.ParseLine(`not json`); err == nil {
+		t.Error("ParseLine() expected error for invalid JSON")
+	}
+}
@@ -12,13 +12,17 @@ import (
 	"time"
 
 	"github.com/shopspring/decimal"
+	"golang-taxi-fare/loggingsystem"
 	"golang-taxi-fare/models"
 )
 
 // Parser defines the interface for parsing time-stamped distance records
 type Parser interface {
-	// ParseStream reads from the provided reader and returns a channel of DistanceRecord
-	// The channel is closed when EOF is reached or an unrecoverable error occurs
+	// ParseStream reads from the provided reader and returns a channel of DistanceRecord.
+	// The channel is closed when EOF is reached or an unrecoverable error occurs.
+	// Parsing happens in a background goroutine; callers must either drain the
+	// returned channel until it closes or cancel ctx so that goroutine can
+	// exit, or it will leak.
 	ParseStream(ctx context.Context, reader io.Reader) (<-chan ParseResult, error)
 	
 	// ParseLine parses a single line in the format "hh:mm:ss.fff xxxxxxxx.f"
@@ -30,11 +34,162 @@ type ParseResult struct {
 	Record models.DistanceRecord
 	Error  error
 	Line   int // Line number for error reporting
+
+	// Kind distinguishes a sentinel result (KindEOF) from an ordinary
+	// per-line outcome (KindRecord, the zero value). Only set to KindEOF
+	// when StreamParser.EmitEOFResult is enabled; see KindEOF's doc comment.
+	Kind ResultKind
 }
 
 // StreamParser implements the Parser interface with streaming capabilities
 type StreamParser struct {
-	// Configuration options can be added here in the future
+	// DedupeWindow is the number of most recent lines to compare each new line
+	// against for exact-duplicate detection. A duplicate within the window is
+	// skipped rather than parsed. This is line-text based, not semantic: two
+	// lines that describe the same record but differ in formatting are not
+	// detected as duplicates. 0 (default) disables deduplication.
+	DedupeWindow int
+
+	// DedupedCount tracks the number of lines skipped as duplicates during the
+	// most recent ParseStream call
+	DedupedCount int
+
+	// AllowScientific loosens the distance pattern to also accept scientific
+	// notation (e.g. "1.234e7"), parsed via decimal.NewFromString which
+	// supports it natively. The default (false) keeps the strict
+	// 8-digit-minimum "xxxxxxxx.f" pattern.
+	//
+	// Note this sacrifices the 8-digit sanity check: the strict pattern's
+	// digit-count requirement is a textual check on the literal, but a
+	// scientific-notation literal's magnitude isn't apparent from its digit
+	// count (e.g. "1e7" represents 10000000, eight digits, from a
+	// three-character literal), so no equivalent length check is applied to
+	// scientific-notation input.
+	AllowScientific bool
+
+	// StartLine makes ParseStream skip lines 1 through StartLine entirely
+	// (no parsing, no dedupe tracking, no ParseResult emitted), while still
+	// counting them so line numbers reported for line StartLine+1 onward
+	// remain absolute. This lets a large input be resumed from a checkpoint
+	// without re-parsing everything before it. 0 (default) disables
+	// skipping.
+	StartLine int
+
+	// Logger, if set, receives a DEBUG-level entry for each line ParseStream
+	// fails to parse, with the line number and error included, so parse
+	// errors are observable without the caller having to log them itself.
+	// Logging is gated on Logger.IsEnabled(loggingsystem.LevelDebug), so
+	// setting a logger with a higher minimum level costs nothing beyond the
+	// gate check. nil (default) disables this logging entirely.
+	Logger loggingsystem.Logger
+
+	// MaxLineBytes caps the length of a single line ParseStream will accept
+	// at face value. A line longer than MaxLineBytes is handled according to
+	// OnLongLine instead of being parsed normally. 0 (default) disables the
+	// check, so lines of any length are read in full.
+	MaxLineBytes int
+
+	// OnLongLine controls how ParseStream responds to a line longer than
+	// MaxLineBytes. It has no effect while MaxLineBytes is 0.
+	OnLongLine LongLineAction
+
+	// MaxRecords stops ParseStream after it has emitted this many
+	// successfully parsed records, closing the result channel without
+	// reading the rest of the input. Blank lines and lines that fail to
+	// parse don't count toward the limit, since they're not successful
+	// records. This short-circuits reading, unlike a downstream guard that
+	// only refuses to act on excess records after the whole stream has
+	// already been read. 0 (default) disables the limit.
+	MaxRecords int
+
+	// EmitEOFResult, when true, makes ParseStream send one terminal
+	// ParseResult with Kind KindEOF, instead of simply closing resultChan
+	// with no results at all, when the input stream ends without containing
+	// a single line. This disambiguates "empty input" from a reader error
+	// encountered before any line (which already surfaces as an
+	// ErrorTypeIO ParseResult) and from the ordinary close-with-no-further-
+	// result that ends a non-empty stream. Has no effect when the stream
+	// contains at least one line, blank or not. The zero value disables
+	// this, matching the historical behavior of closing resultChan with no
+	// results on empty input.
+	EmitEOFResult bool
+
+	// stats accumulates parse quality counters for the most recent
+	// ParseStream call, exposed via Stats().
+	stats ParseStats
+}
+
+// ResultKind categorizes what a ParseResult represents. The zero value,
+// KindRecord, covers every ordinary per-line parse outcome (successful or
+// failed), so existing code that never inspects Kind keeps working
+// unchanged.
+type ResultKind int
+
+const (
+	// KindRecord is an ordinary per-line parse outcome, carried in Record or
+	// Error as usual.
+	KindRecord ResultKind = iota
+
+	// KindEOF marks a terminal sentinel ParseResult emitted when
+	// StreamParser.EmitEOFResult is set and the input stream ended having
+	// contained no lines at all. Record and Error are both zero-valued.
+	KindEOF
+)
+
+// String returns a human-readable name for the ResultKind.
+func (k ResultKind) String() string {
+	switch k {
+	case KindEOF:
+		return "eof"
+	default:
+		return "record"
+	}
+}
+
+// LongLineAction selects how ParseStream responds to a line longer than
+// StreamParser.MaxLineBytes.
+type LongLineAction int
+
+const (
+	// LongLineError (the default) reports the line as a format error and
+	// does not attempt to parse any part of it.
+	LongLineError LongLineAction = iota
+
+	// LongLineTruncate cuts the line down to MaxLineBytes bytes and attempts
+	// to parse that prefix as usual, rather than rejecting the line
+	// outright. The prefix may or may not match the expected line format.
+	LongLineTruncate
+
+	// LongLineSkip drops the line as if it were blank, emitting a WARN-level
+	// log entry (via StreamParser.Logger, if set) instead of a ParseResult.
+	LongLineSkip
+)
+
+// String returns a human-readable name for the LongLineAction.
+func (a LongLineAction) String() string {
+	switch a {
+	case LongLineError:
+		return "error"
+	case LongLineTruncate:
+		return "truncate"
+	case LongLineSkip:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseStats summarizes a completed ParseStream call: how many lines the
+// scanner saw in total, how many were skipped for being blank, how many
+// records were parsed successfully, and how many failed with each
+// ErrorType. Available via StreamParser.Stats() once the result channel
+// has closed; reading it while a ParseStream call is still running is
+// racy, since its background goroutine mutates the same counters.
+type ParseStats struct {
+	TotalLines    int
+	BlankLines    int
+	RecordsParsed int
+	ErrorsByType  map[ErrorType]int
 }
 
 // NewParser creates a new StreamParser instance
@@ -92,9 +247,24 @@ const timestampLayout = "15:04:05.000"
 // distancePattern defines the regex pattern for distance validation (8+ digits, decimal point, 1+ fractional digits)
 var distancePattern = regexp.MustCompile(`^\d{8,}\.\d+$`)
 
+// distancePatternScientific additionally accepts scientific notation (e.g.
+// "1.234e7"), used in place of distancePattern when AllowScientific is set
+var distancePatternScientific = regexp.MustCompile(`^(\d{8,}\.\d+|\d+(\.\d+)?[eE][+-]?\d+)$`)
+
 // linePattern defines the complete line format: timestamp single-space distance
 var linePattern = regexp.MustCompile(`^(\d{2}:\d{2}:\d{2}\.\d{3}) (\d{8,}\.\d+)$`)
 
+// linePatternScientific is linePattern with the distance group widened to
+// also accept scientific notation, used in place of linePattern when
+// AllowScientific is set
+var linePatternScientific = regexp.MustCompile(`^(\d{2}:\d{2}:\d{2}\.\d{3}) (\d{8,}\.\d+|\d+(?:\.\d+)?[eE][+-]?\d+)$`)
+
+// swappedLinePattern matches a line whose fields are in distance-then-timestamp
+// order, the opposite of the expected timestamp-then-distance order. It's used
+// only to produce a more helpful diagnostic when the normal pattern fails to
+// match; it never causes a line to be accepted.
+var swappedLinePattern = regexp.MustCompile(`^(\d{8,}\.\d+) (\d{2}:\d{2}:\d{2}\.\d{3})$`)
+
 // parseTimestamp parses a timestamp string in the format "hh:mm:ss.fff"
 func parseTimestamp(timestampStr string) (time.Time, error) {
 	if timestampStr == "" {
@@ -201,8 +371,12 @@ func parseDistance(distanceStr string) (decimal.Decimal, error) {
 }
 
 // validateDistanceFormat performs format validation on distance string
-func validateDistanceFormat(distanceStr string) error {
-	if !distancePattern.MatchString(distanceStr) {
+func validateDistanceFormat(distanceStr string, allowScientific bool) error {
+	pattern := distancePattern
+	if allowScientific {
+		pattern = distancePatternScientific
+	}
+	if !pattern.MatchString(distanceStr) {
 		return &ParsingError{
 			Type:    ErrorTypeDistance,
 			Message: "invalid distance format, expected xxxxxxxx.f (8+ digits, decimal point, 1+ fractional digits)",
@@ -213,18 +387,20 @@ func validateDistanceFormat(distanceStr string) error {
 }
 
 // parseDistanceWithValidation combines format validation and parsing
-func parseDistanceWithValidation(distanceStr string) (decimal.Decimal, error) {
+func parseDistanceWithValidation(distanceStr string, allowScientific bool) (decimal.Decimal, error) {
 	// First validate the format structure
-	if err := validateDistanceFormat(distanceStr); err != nil {
+	if err := validateDistanceFormat(distanceStr, allowScientific); err != nil {
 		return decimal.Zero, err
 	}
-	
+
 	// Then parse the distance
 	return parseDistance(distanceStr)
 }
 
-// parseLine parses a single line in the format "hh:mm:ss.fff xxxxxxxx.f"
-func parseLine(line string, lineNum int) (models.DistanceRecord, error) {
+// parseLine parses a single line in the format "hh:mm:ss.fff xxxxxxxx.f". When
+// allowScientific is set, the distance may also be given in scientific
+// notation (e.g. "1.234e7").
+func parseLine(line string, lineNum int, allowScientific bool) (models.DistanceRecord, error) {
 	// Skip blank lines
 	line = strings.TrimSpace(line)
 	if line == "" {
@@ -235,21 +411,29 @@ func parseLine(line string, lineNum int) (models.DistanceRecord, error) {
 			Input:   line,
 		}
 	}
-	
+
 	// Validate overall line format
-	matches := linePattern.FindStringSubmatch(line)
-	if len(matches) != 3 {
+	pattern := linePattern
+	if allowScientific {
+		pattern = linePatternScientific
+	}
+	matches := pattern.FindStringSubmatch(line)
+	if len(matches) < 3 {
+		message := "invalid line format, expected 'hh:mm:ss.fff xxxxxxxx.f'"
+		if swappedLinePattern.MatchString(line) {
+			message = "fields appear swapped (distance before timestamp)"
+		}
 		return models.DistanceRecord{}, &ParsingError{
 			Type:    ErrorTypeFormat,
-			Message: "invalid line format, expected 'hh:mm:ss.fff xxxxxxxx.f'",
+			Message: message,
 			Line:    lineNum,
 			Input:   line,
 		}
 	}
-	
+
 	timestampStr := matches[1]
 	distanceStr := matches[2]
-	
+
 	// Parse timestamp using existing function
 	timestamp, err := parseTimestampWithValidation(timestampStr)
 	if err != nil {
@@ -259,9 +443,9 @@ func parseLine(line string, lineNum int) (models.DistanceRecord, error) {
 		}
 		return models.DistanceRecord{}, err
 	}
-	
+
 	// Parse distance using existing function
-	distance, err := parseDistanceWithValidation(distanceStr)
+	distance, err := parseDistanceWithValidation(distanceStr, allowScientific)
 	if err != nil {
 		// Convert to include line number
 		if pe, ok := err.(*ParsingError); ok {
@@ -269,88 +453,248 @@ func parseLine(line string, lineNum int) (models.DistanceRecord, error) {
 		}
 		return models.DistanceRecord{}, err
 	}
-	
+
 	return models.DistanceRecord{
 		Timestamp: timestamp,
 		Distance:  distance,
 	}, nil
 }
 
+// isDuplicateLine reports whether line matches any of the recently seen lines
+func isDuplicateLine(recentLines []string, line string) bool {
+	for _, seen := range recentLines {
+		if seen == line {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseLine implements single line parsing for the Parser interface
+// Reset clears state accumulated by a previous ParseStream call —
+// DedupedCount and the counters returned by Stats — while preserving configuration (DedupeWindow,
+// AllowScientific, StartLine), so a single configured StreamParser can be
+// reused across multiple streams (e.g. one file after another) instead of
+// being reconstructed for each one. ParseStream already resets DedupedCount
+// and its dedupe window at the start of each call, so Reset is mainly useful
+// for a caller that wants stats cleared between streams without waiting for
+// the next ParseStream call to start, or that wants to make the boundary
+// between streams explicit in its own code.
+//
+// Reset must not be called concurrently with an active ParseStream, since
+// ParseStream's background goroutine mutates sp.DedupedCount as it runs.
+func (sp *StreamParser) Reset() {
+	sp.DedupedCount = 0
+	sp.stats = ParseStats{}
+}
+
+// Stats returns parse quality counters for the most recent ParseStream
+// call: total lines seen, blank lines skipped, records parsed, and errors
+// by ErrorType. Call it after the channel returned by ParseStream has
+// closed; reading it while ParseStream is still running is racy. The
+// returned ErrorsByType map is a copy, safe for the caller to inspect
+// without affecting sp's internal state.
+func (sp *StreamParser) Stats() ParseStats {
+	errorsByType := make(map[ErrorType]int, len(sp.stats.ErrorsByType))
+	for errType, count := range sp.stats.ErrorsByType {
+		errorsByType[errType] = count
+	}
+
+	return ParseStats{
+		TotalLines:    sp.stats.TotalLines,
+		BlankLines:    sp.stats.BlankLines,
+		RecordsParsed: sp.stats.RecordsParsed,
+		ErrorsByType:  errorsByType,
+	}
+}
+
 func (sp *StreamParser) ParseLine(line string) (models.DistanceRecord, error) {
-	return parseLine(line, 0) // Line number 0 for standalone parsing
+	return parseLine(line, 0, sp.AllowScientific) // Line number 0 for standalone parsing
 }
 
 // ParseStream implements streaming parsing with context support
 func (sp *StreamParser) ParseStream(ctx context.Context, reader io.Reader) (<-chan ParseResult, error) {
 	resultChan := make(chan ParseResult, 10) // Buffered channel for better performance
-	
+
+	sp.DedupedCount = 0
+	sp.stats = ParseStats{ErrorsByType: make(map[ErrorType]int)}
+	var recentLines []string
+	if sp.DedupeWindow > 0 {
+		recentLines = make([]string, 0, sp.DedupeWindow)
+	}
+
 	go func() {
 		defer close(resultChan)
-		
-		scanner := bufio.NewScanner(reader)
+
+		// bufio.Reader.ReadString has no fixed token-size cap, unlike
+		// bufio.Scanner (which aborts the whole stream with ErrTooLong on
+		// overflow), so it's the primitive that lets MaxLineBytes/OnLongLine
+		// recover on a per-line basis instead of failing the entire stream.
+		bufReader := bufio.NewReader(reader)
 		lineNum := 0
-		
-		for scanner.Scan() {
-			lineNum++
-			
-			// Check for context cancellation
-			select {
-			case <-ctx.Done():
-				resultChan <- ParseResult{
-					Record: models.DistanceRecord{},
-					Error:  ctx.Err(),
-					Line:   lineNum,
+
+		for {
+			rawLine, readErr := bufReader.ReadString('\n')
+			line := strings.TrimRight(rawLine, "\r\n")
+
+			if line != "" || readErr == nil {
+				lineNum++
+				sp.stats.TotalLines++
+
+				// Check for context cancellation. The send itself also selects
+				// on ctx.Done() so the goroutine can't block forever if the
+				// caller has stopped draining resultChan after cancelling.
+				select {
+				case <-ctx.Done():
+					select {
+					case resultChan <- ParseResult{
+						Record: models.DistanceRecord{},
+						Error:  ctx.Err(),
+						Line:   lineNum,
+					}:
+					case <-ctx.Done():
+					}
+					return
+				default:
+					// Continue processing
+				}
+
+				// Skip lines up to the checkpoint entirely, without checking
+				// length, parsing, or dedupe tracking; lineNum keeps counting
+				// so later line numbers stay absolute
+				if lineNum <= sp.StartLine {
+					goto nextLine
+				}
+
+				if sp.MaxLineBytes > 0 && len(line) > sp.MaxLineBytes {
+					switch sp.OnLongLine {
+					case LongLineSkip:
+						sp.stats.BlankLines++
+						if sp.Logger != nil && sp.Logger.IsEnabled(loggingsystem.LevelWarn) {
+							sp.Logger.Warn("Skipping line exceeding maximum length",
+								"line_number", lineNum,
+								"length", len(line),
+								"max_line_bytes", sp.MaxLineBytes,
+							)
+						}
+						goto nextLine
+					case LongLineTruncate:
+						line = line[:sp.MaxLineBytes]
+					default: // LongLineError
+						err := &ParsingError{
+							Type:    ErrorTypeFormat,
+							Message: fmt.Sprintf("line exceeds maximum allowed length of %d bytes", sp.MaxLineBytes),
+							Line:    lineNum,
+							Input:   line,
+						}
+						sp.stats.ErrorsByType[err.Type]++
+						if sp.Logger != nil && sp.Logger.IsEnabled(loggingsystem.LevelDebug) {
+							sp.Logger.Debug("Failed to parse line",
+								"line_number", lineNum,
+								"error", err.Error(),
+							)
+						}
+						select {
+						case resultChan <- ParseResult{Error: err, Line: lineNum}:
+						case <-ctx.Done():
+							return
+						}
+						goto nextLine
+					}
+				}
+
+				if sp.processLine(lineNum, line, &recentLines, resultChan, ctx) {
+					return
 				}
-				return
-			default:
-				// Continue processing
-			}
-			
-			line := scanner.Text()
-			
-			// Skip blank lines silently
-			if strings.TrimSpace(line) == "" {
-				continue
-			}
-			
-			// Parse the line
-			record, err := parseLine(line, lineNum)
-			
-			result := ParseResult{
-				Record: record,
-				Error:  err,
-				Line:   lineNum,
 			}
-			
-			// Send result to channel
-			select {
-			case resultChan <- result:
-				// Successfully sent
-			case <-ctx.Done():
-				// Context cancelled while sending
+
+		nextLine:
+			if readErr != nil {
+				if readErr != io.EOF {
+					select {
+					case resultChan <- ParseResult{
+						Record: models.DistanceRecord{},
+						Error: &ParsingError{
+							Type:    ErrorTypeIO,
+							Message: fmt.Sprintf("read error: %v", readErr),
+							Line:    lineNum,
+							Input:   "",
+						},
+						Line: lineNum,
+					}:
+					case <-ctx.Done():
+						// Context cancelled
+					}
+				} else if sp.EmitEOFResult && sp.stats.TotalLines == 0 {
+					select {
+					case resultChan <- ParseResult{Kind: KindEOF}:
+					case <-ctx.Done():
+						// Context cancelled
+					}
+				}
 				return
 			}
 		}
-		
-		// Check for scanner errors
-		if err := scanner.Err(); err != nil {
-			select {
-			case resultChan <- ParseResult{
-				Record: models.DistanceRecord{},
-				Error: &ParsingError{
-					Type:    ErrorTypeIO,
-					Message: fmt.Sprintf("scanner error: %v", err),
-					Line:    lineNum,
-					Input:   "",
-				},
-				Line: lineNum,
-			}:
-			case <-ctx.Done():
-				// Context cancelled
-			}
-		}
 	}()
-	
+
 	return resultChan, nil
+}
+
+// processLine handles a single non-long line: blank/dedupe skipping,
+// parsing, stats/logger bookkeeping, and sending the ParseResult. It
+// returns true if the caller's goroutine should stop reading further
+// input, either because ctx was cancelled while sending or because this
+// result reached StreamParser.MaxRecords.
+func (sp *StreamParser) processLine(lineNum int, line string, recentLines *[]string, resultChan chan<- ParseResult, ctx context.Context) bool {
+	// Skip blank lines silently
+	if strings.TrimSpace(line) == "" {
+		sp.stats.BlankLines++
+		return false
+	}
+
+	// Skip exact-duplicate lines seen within the dedupe window
+	if sp.DedupeWindow > 0 {
+		if isDuplicateLine(*recentLines, line) {
+			sp.DedupedCount++
+			return false
+		}
+		*recentLines = append(*recentLines, line)
+		if len(*recentLines) > sp.DedupeWindow {
+			*recentLines = (*recentLines)[1:]
+		}
+	}
+
+	// Parse the line
+	record, err := parseLine(line, lineNum, sp.AllowScientific)
+
+	if err != nil {
+		if parsingErr, ok := err.(*ParsingError); ok {
+			sp.stats.ErrorsByType[parsingErr.Type]++
+		}
+		if sp.Logger != nil && sp.Logger.IsEnabled(loggingsystem.LevelDebug) {
+			sp.Logger.Debug("Failed to parse line",
+				"line_number", lineNum,
+				"error", err.Error(),
+			)
+		}
+	} else {
+		sp.stats.RecordsParsed++
+	}
+
+	result := ParseResult{
+		Record: record,
+		Error:  err,
+		Line:   lineNum,
+	}
+
+	// Send result to channel
+	select {
+	case resultChan <- result:
+		if err == nil && sp.MaxRecords > 0 && sp.stats.RecordsParsed >= sp.MaxRecords {
+			return true
+		}
+		return false
+	case <-ctx.Done():
+		return true
+	}
 }
\ No newline at end of file
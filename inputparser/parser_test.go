@@ -142,6 +142,48 @@ func TestStreamParser_ParseStream_Implemented(t *testing.T) {
 	}
 }
 
+func TestStreamParser_Reset(t *testing.T) {
+	parser := &StreamParser{DedupeWindow: 2}
+	ctx := context.Background()
+
+	firstStream := strings.NewReader(strings.Join([]string{
+		"12:34:56.789 12345678.5",
+		"12:34:56.789 12345678.5", // duplicate, deduped
+		"12:34:57.789 12345679.5",
+	}, "\n"))
+	for range drainResults(parser, ctx, firstStream) {
+	}
+	if parser.DedupedCount != 1 {
+		t.Fatalf("first stream: DedupedCount = %d, want 1", parser.DedupedCount)
+	}
+
+	parser.Reset()
+	if parser.DedupedCount != 0 {
+		t.Errorf("after Reset(): DedupedCount = %d, want 0", parser.DedupedCount)
+	}
+
+	secondStream := strings.NewReader("12:34:58.789 12345680.5")
+	for range drainResults(parser, ctx, secondStream) {
+	}
+	if parser.DedupedCount != 0 {
+		t.Errorf("second stream: DedupedCount = %d, want 0 (independent of first stream)", parser.DedupedCount)
+	}
+}
+
+// drainResults runs ParseStream and collects every ParseResult, so callers
+// can assert on parser state after the stream has been fully consumed.
+func drainResults(parser *StreamParser, ctx context.Context, reader *strings.Reader) []ParseResult {
+	channel, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		return nil
+	}
+	results := make([]ParseResult, 0)
+	for result := range channel {
+		results = append(results, result)
+	}
+	return results
+}
+
 func TestStreamParser_ParseLine_Implemented(t *testing.T) {
 	parser := &StreamParser{}
 	
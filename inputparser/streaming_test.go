@@ -1,11 +1,15 @@
 package inputparser
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 
+	"golang-taxi-fare/loggingsystem"
 	"golang-taxi-fare/models"
 )
 
@@ -97,11 +101,18 @@ func TestParseLine(t *testing.T) {
 			wantErr:     true,
 			expectedErr: "invalid line format",
 		},
+		{
+			name:        "swapped fields",
+			input:       "12345678.5 12:34:56.789",
+			lineNum:     9,
+			wantErr:     true,
+			expectedErr: "fields appear swapped (distance before timestamp)",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseLine(tt.input, tt.lineNum)
+			result, err := parseLine(tt.input, tt.lineNum, false)
 			
 			if tt.wantErr {
 				if err == nil {
@@ -333,6 +344,481 @@ invalid line format
 	})
 }
 
+func TestStreamParser_DedupeWindow(t *testing.T) {
+	t.Run("duplicate within window is skipped", func(t *testing.T) {
+		input := `12:34:56.789 12345678.5
+12:34:56.789 12345678.5
+00:00:00.000 87654321.123`
+
+		parser := &StreamParser{DedupeWindow: 2}
+		ctx := context.Background()
+		reader := strings.NewReader(input)
+
+		resultChan, err := parser.ParseStream(ctx, reader)
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result := range resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 2 {
+			t.Fatalf("ParseStream() got %d results, want 2 (duplicate should be skipped)", len(results))
+		}
+
+		if parser.DedupedCount != 1 {
+			t.Errorf("DedupedCount = %d, want 1", parser.DedupedCount)
+		}
+	})
+
+	t.Run("duplicate outside window is not skipped", func(t *testing.T) {
+		input := `12:34:56.789 12345678.5
+00:00:00.000 87654321.123
+23:59:59.999 99999999.9
+12:34:56.789 12345678.5`
+
+		parser := &StreamParser{DedupeWindow: 2}
+		ctx := context.Background()
+		reader := strings.NewReader(input)
+
+		resultChan, err := parser.ParseStream(ctx, reader)
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result := range resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 4 {
+			t.Errorf("ParseStream() got %d results, want 4 (duplicate outside window should be parsed)", len(results))
+		}
+
+		if parser.DedupedCount != 0 {
+			t.Errorf("DedupedCount = %d, want 0", parser.DedupedCount)
+		}
+	})
+
+	t.Run("dedupe disabled by default", func(t *testing.T) {
+		input := `12:34:56.789 12345678.5
+12:34:56.789 12345678.5`
+
+		parser := &StreamParser{}
+		ctx := context.Background()
+		reader := strings.NewReader(input)
+
+		resultChan, err := parser.ParseStream(ctx, reader)
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result := range resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 2 {
+			t.Errorf("ParseStream() got %d results, want 2 (dedupe disabled by default)", len(results))
+		}
+	})
+}
+
+func TestStreamParser_Stats(t *testing.T) {
+	input := `12:34:56.789 12345678.5
+
+invalid input
+00:00:00.000 notanumber
+23:59:59.999 87654321.1`
+
+	parser := &StreamParser{}
+	ctx := context.Background()
+	reader := strings.NewReader(input)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	for range resultChan {
+		// Drain the channel; Stats() is only valid once it's closed.
+	}
+
+	stats := parser.Stats()
+
+	if stats.TotalLines != 5 {
+		t.Errorf("TotalLines = %d, want 5", stats.TotalLines)
+	}
+	if stats.BlankLines != 1 {
+		t.Errorf("BlankLines = %d, want 1", stats.BlankLines)
+	}
+	if stats.RecordsParsed != 2 {
+		t.Errorf("RecordsParsed = %d, want 2", stats.RecordsParsed)
+	}
+	if got := stats.ErrorsByType[ErrorTypeFormat]; got != 2 {
+		t.Errorf("ErrorsByType[ErrorTypeFormat] = %d, want 2", got)
+	}
+}
+
+func TestStreamParser_Logger(t *testing.T) {
+	input := `12:34:56.789 12345678.5
+invalid input`
+
+	var buf bytes.Buffer
+	logger := loggingsystem.NewLoggerWithOptions(&buf, loggingsystem.LevelDebug)
+
+	parser := &StreamParser{Logger: logger}
+	ctx := context.Background()
+	reader := strings.NewReader(input)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	for range resultChan {
+		// Drain the channel
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Failed to parse line") {
+		t.Errorf("Expected a debug log for the malformed line, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"line_number":2`) {
+		t.Errorf("Expected the debug log to include line_number 2, got:\n%s", output)
+	}
+}
+
+func TestStreamParser_Logger_NotEnabled(t *testing.T) {
+	input := "invalid input"
+
+	var buf bytes.Buffer
+	logger := loggingsystem.NewLoggerWithOptions(&buf, loggingsystem.LevelInfo)
+
+	parser := &StreamParser{Logger: logger}
+	ctx := context.Background()
+	reader := strings.NewReader(input)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	for range resultChan {
+		// Drain the channel
+	}
+
+	if buf.String() != "" {
+		t.Errorf("Expected no log output when DEBUG is disabled, got:\n%s", buf.String())
+	}
+}
+
+func TestStreamParser_AllowScientific(t *testing.T) {
+	t.Run("scientific notation rejected by default", func(t *testing.T) {
+		parser := &StreamParser{}
+
+		_, err := parser.ParseLine("12:34:56.789 1.2345678e7")
+		if err == nil {
+			t.Error("ParseLine() expected error for scientific notation by default, got nil")
+		}
+	})
+
+	t.Run("scientific notation parsed when allowed", func(t *testing.T) {
+		parser := &StreamParser{AllowScientific: true}
+
+		result, err := parser.ParseLine("12:34:56.789 1.2345678e7")
+		if err != nil {
+			t.Fatalf("ParseLine() unexpected error = %v", err)
+		}
+
+		expectedDistance := mustDecimal("12345678")
+		if !result.Distance.Equal(expectedDistance) {
+			t.Errorf("ParseLine() distance = %v, want %v", result.Distance, expectedDistance)
+		}
+	})
+
+	t.Run("strict format still accepted when scientific is allowed", func(t *testing.T) {
+		parser := &StreamParser{AllowScientific: true}
+
+		result, err := parser.ParseLine("12:34:56.789 12345678.5")
+		if err != nil {
+			t.Fatalf("ParseLine() unexpected error = %v", err)
+		}
+
+		expectedDistance := mustDecimal("12345678.5")
+		if !result.Distance.Equal(expectedDistance) {
+			t.Errorf("ParseLine() distance = %v, want %v", result.Distance, expectedDistance)
+		}
+	})
+}
+
+func TestStreamParser_StartLine(t *testing.T) {
+	input := `12:34:56.789 12345678.5
+00:00:00.000 87654321.123
+23:59:59.999 99999999.9`
+
+	parser := &StreamParser{StartLine: 2}
+	ctx := context.Background()
+	reader := strings.NewReader(input)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	var results []ParseResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("ParseStream() got %d results, want 1 (first 2 lines skipped)", len(results))
+	}
+
+	if results[0].Line != 3 {
+		t.Errorf("ParseStream() result line number = %d, want 3 (absolute, not reset)", results[0].Line)
+	}
+
+	expectedTime := mustParseTime("23:59:59.999")
+	if !results[0].Record.Timestamp.Equal(expectedTime) {
+		t.Errorf("ParseStream() record timestamp = %v, want %v", results[0].Record.Timestamp, expectedTime)
+	}
+}
+
+func TestStreamParser_MaxRecords(t *testing.T) {
+	input := `12:34:56.789 12345678.5
+
+00:00:00.000 87654321.123
+23:59:59.999 99999999.9
+01:02:03.456 11111111.1`
+
+	parser := &StreamParser{MaxRecords: 2}
+	ctx := context.Background()
+	reader := strings.NewReader(input)
+
+	resultChan, err := parser.ParseStream(ctx, reader)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	var results []ParseResult
+	for result, ok := <-resultChan; ok; result, ok = <-resultChan {
+		results = append(results, result)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("ParseStream() got %d results, want 2 (MaxRecords reached)", len(results))
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("ParseStream() unexpected error: %v", result.Error)
+		}
+	}
+
+	// The blank line before the 2nd successful record shouldn't have
+	// counted toward the limit, so the 2nd result is line 3, not line 2.
+	if results[1].Line != 3 {
+		t.Errorf("ParseStream() result[1] line = %d, want 3", results[1].Line)
+	}
+
+	stats := parser.Stats()
+	if stats.RecordsParsed != 2 {
+		t.Errorf("ParseStream() Stats().RecordsParsed = %d, want 2", stats.RecordsParsed)
+	}
+}
+
+func TestStreamParser_EmitEOFResult(t *testing.T) {
+	t.Run("empty input emits a single KindEOF sentinel", func(t *testing.T) {
+		parser := &StreamParser{EmitEOFResult: true}
+		ctx := context.Background()
+		reader := strings.NewReader("")
+
+		resultChan, err := parser.ParseStream(ctx, reader)
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result, ok := <-resultChan; ok; result, ok = <-resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("ParseStream() got %d results, want 1 (KindEOF sentinel)", len(results))
+		}
+		if results[0].Kind != KindEOF {
+			t.Errorf("ParseStream() result Kind = %v, want KindEOF", results[0].Kind)
+		}
+		if results[0].Error != nil {
+			t.Errorf("ParseStream() unexpected error on KindEOF result: %v", results[0].Error)
+		}
+	})
+
+	t.Run("disabled by default: empty input produces no results", func(t *testing.T) {
+		parser := &StreamParser{}
+		ctx := context.Background()
+		reader := strings.NewReader("")
+
+		resultChan, err := parser.ParseStream(ctx, reader)
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result, ok := <-resultChan; ok; result, ok = <-resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 0 {
+			t.Errorf("ParseStream() got %d results, want 0 (EmitEOFResult disabled)", len(results))
+		}
+	})
+
+	t.Run("non-empty input never emits a KindEOF sentinel, even when enabled", func(t *testing.T) {
+		parser := &StreamParser{EmitEOFResult: true}
+		ctx := context.Background()
+		reader := strings.NewReader("12:34:56.789 12345678.5")
+
+		resultChan, err := parser.ParseStream(ctx, reader)
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result, ok := <-resultChan; ok; result, ok = <-resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("ParseStream() got %d results, want 1 (the parsed record)", len(results))
+		}
+		if results[0].Kind != KindRecord {
+			t.Errorf("ParseStream() result Kind = %v, want KindRecord", results[0].Kind)
+		}
+	})
+}
+
+func TestStreamParser_MaxLineBytes(t *testing.T) {
+	longLine := "12:34:56.789 " + strings.Repeat("1", 40) + ".5"
+	input := "00:00:00.000 87654321.123\n" + longLine + "\n23:59:59.999 99999999.9"
+
+	t.Run("LongLineError reports a format error and does not parse the line", func(t *testing.T) {
+		parser := &StreamParser{MaxLineBytes: 30} // default OnLongLine is LongLineError
+		ctx := context.Background()
+
+		resultChan, err := parser.ParseStream(ctx, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result := range resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 3 {
+			t.Fatalf("ParseStream() got %d results, want 3", len(results))
+		}
+
+		if results[1].Error == nil {
+			t.Fatal("ParseStream() expected an error for the over-long line, got nil")
+		}
+		parsingErr, ok := results[1].Error.(*ParsingError)
+		if !ok {
+			t.Fatalf("ParseStream() error type = %T, want *ParsingError", results[1].Error)
+		}
+		if parsingErr.Type != ErrorTypeFormat {
+			t.Errorf("ParseStream() error type = %v, want %v", parsingErr.Type, ErrorTypeFormat)
+		}
+		if !strings.Contains(parsingErr.Message, "exceeds maximum allowed length of 30 bytes") {
+			t.Errorf("ParseStream() error message = %q, missing expected text", parsingErr.Message)
+		}
+
+		stats := parser.Stats()
+		if stats.ErrorsByType[ErrorTypeFormat] != 1 {
+			t.Errorf("ParseStream() Stats().ErrorsByType[ErrorTypeFormat] = %d, want 1", stats.ErrorsByType[ErrorTypeFormat])
+		}
+	})
+
+	t.Run("LongLineTruncate parses the truncated prefix", func(t *testing.T) {
+		parser := &StreamParser{MaxLineBytes: 30, OnLongLine: LongLineTruncate}
+		ctx := context.Background()
+
+		resultChan, err := parser.ParseStream(ctx, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result := range resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 3 {
+			t.Fatalf("ParseStream() got %d results, want 3", len(results))
+		}
+
+		// The 30-byte prefix of longLine ("12:34:56.789 " and 17 ones, with
+		// no decimal point) no longer matches the line pattern, so it's
+		// expected to fail to parse, but
+		// as a format error rather than the long-line error.
+		if results[1].Error == nil {
+			t.Fatal("ParseStream() expected the truncated prefix to fail parsing, got nil error")
+		}
+		if strings.Contains(results[1].Error.Error(), "exceeds maximum allowed length") {
+			t.Errorf("ParseStream() got the long-line error instead of a parse error on the truncated prefix: %v", results[1].Error)
+		}
+	})
+
+	t.Run("LongLineSkip drops the line as if blank", func(t *testing.T) {
+		parser := &StreamParser{MaxLineBytes: 30, OnLongLine: LongLineSkip}
+		ctx := context.Background()
+
+		resultChan, err := parser.ParseStream(ctx, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+
+		var results []ParseResult
+		for result := range resultChan {
+			results = append(results, result)
+		}
+
+		if len(results) != 2 {
+			t.Fatalf("ParseStream() got %d results, want 2 (long line dropped)", len(results))
+		}
+		if results[0].Line != 1 || results[1].Line != 3 {
+			t.Errorf("ParseStream() result lines = [%d, %d], want [1, 3]", results[0].Line, results[1].Line)
+		}
+
+		stats := parser.Stats()
+		if stats.BlankLines != 1 {
+			t.Errorf("ParseStream() Stats().BlankLines = %d, want 1", stats.BlankLines)
+		}
+	})
+
+	t.Run("LongLineSkip logs a warning", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := loggingsystem.NewLoggerWithOptions(&buf, loggingsystem.LevelWarn)
+		parser := &StreamParser{MaxLineBytes: 30, OnLongLine: LongLineSkip, Logger: logger}
+		ctx := context.Background()
+
+		resultChan, err := parser.ParseStream(ctx, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseStream() unexpected error = %v", err)
+		}
+		for range resultChan {
+			// Drain the channel
+		}
+
+		if !strings.Contains(buf.String(), "Skipping line exceeding maximum length") {
+			t.Errorf("Expected a warning log for the skipped line, got:\n%s", buf.String())
+		}
+	})
+}
+
 func TestLinePattern(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -409,6 +895,45 @@ func TestLinePattern(t *testing.T) {
 	}
 }
 
+func TestStreamParser_ParseStream_CancelMidStreamDoesNotLeakGoroutine(t *testing.T) {
+	parser := &StreamParser{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pr, pw := io.Pipe()
+	go func() {
+		// Keep writing well past the channel's buffer, so the parsing
+		// goroutine is still trying to send when the context is cancelled.
+		for i := 0; i < 100; i++ {
+			if _, err := io.WriteString(pw, "12:34:56.789 12345678.5\n"); err != nil {
+				return
+			}
+		}
+		pw.Close()
+	}()
+
+	before := runtime.NumGoroutine()
+
+	resultChan, err := parser.ParseStream(ctx, pr)
+	if err != nil {
+		t.Fatalf("ParseStream() unexpected error = %v", err)
+	}
+
+	// Read exactly one result, then stop draining and cancel, without ever
+	// reading resultChan to closure.
+	<-resultChan
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Errorf("Expected parsing goroutine to exit after cancellation, goroutine count before=%d after=%d", before, runtime.NumGoroutine())
+}
+
 // Helper functions for tests
 func mustParseTime(timeStr string) time.Time {
 	t, err := time.Parse(timestampLayout, timeStr)
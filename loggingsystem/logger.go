@@ -119,6 +119,13 @@ type StructuredLogger struct {
 	recordID        string
 	processingState string
 	output          io.Writer // Keep track of output for level changes
+
+	// componentLevels holds optional per-component minimum levels set via
+	// SetComponentLevel. It is shared by reference across every logger
+	// derived from this one through WithContext/WithComponent/WithRecordID/
+	// WithProcessingState, so an override applies regardless of which
+	// derived logger SetComponentLevel was called on.
+	componentLevels map[string]LogLevel
 }
 
 // NewLogger creates a new StructuredLogger with JSON output to stderr
@@ -128,19 +135,23 @@ func NewLogger() Logger {
 
 // NewLoggerWithOptions creates a new StructuredLogger with custom options
 func NewLoggerWithOptions(output io.Writer, minLevel LogLevel) Logger {
-	// Create JSON handler for structured logging
+	// The handler itself is always left at its most permissive level; the
+	// actual level gating happens in LogWithLevel via isEnabledForComponent,
+	// so that a later SetComponentLevel override can allow a component to
+	// log below minLevel without needing to rebuild the handler.
 	handler := slog.NewJSONHandler(output, &slog.HandlerOptions{
-		Level:     minLevel.ToSlogLevel(),
+		Level:     LevelDebug.ToSlogLevel(),
 		AddSource: false, // We'll add our own contextual information
 	})
-	
+
 	slogger := slog.New(handler)
 	
 	return &StructuredLogger{
-		slogger:     slogger,
-		minLevel:    minLevel,
-		baseContext: make(map[string]interface{}),
-		output:      output,
+		slogger:         slogger,
+		minLevel:        minLevel,
+		baseContext:     make(map[string]interface{}),
+		output:          output,
+		componentLevels: make(map[string]LogLevel),
 	}
 }
 
@@ -166,7 +177,7 @@ func (sl *StructuredLogger) Error(message string, keyValues ...interface{}) {
 
 // LogWithLevel logs a message at the specified level with context
 func (sl *StructuredLogger) LogWithLevel(level LogLevel, message string, keyValues ...interface{}) {
-	if !sl.IsEnabled(level) {
+	if !sl.isEnabledForComponent(level) {
 		return
 	}
 	
@@ -231,6 +242,7 @@ func (sl *StructuredLogger) WithContext(context map[string]interface{}) Logger {
 		recordID:        sl.recordID,
 		processingState: sl.processingState,
 		output:          sl.output,
+		componentLevels: sl.componentLevels,
 	}
 }
 
@@ -244,6 +256,7 @@ func (sl *StructuredLogger) WithComponent(component string) Logger {
 		recordID:        sl.recordID,
 		processingState: sl.processingState,
 		output:          sl.output,
+		componentLevels: sl.componentLevels,
 	}
 }
 
@@ -257,6 +270,7 @@ func (sl *StructuredLogger) WithRecordID(recordID string) Logger {
 		recordID:        recordID,
 		processingState: sl.processingState,
 		output:          sl.output,
+		componentLevels: sl.componentLevels,
 	}
 }
 
@@ -270,26 +284,54 @@ func (sl *StructuredLogger) WithProcessingState(state string) Logger {
 		recordID:        sl.recordID,
 		processingState: state,
 		output:          sl.output,
+		componentLevels: sl.componentLevels,
 	}
 }
 
 // SetLevel sets the minimum logging level
 func (sl *StructuredLogger) SetLevel(level LogLevel) {
 	sl.minLevel = level
-	
-	// Update the slog handler's level with the original output
+
+	// Rebuild the slog handler against the original output. The handler
+	// itself stays at its most permissive level (see NewLoggerWithOptions);
+	// minLevel is enforced in LogWithLevel via isEnabledForComponent.
 	handler := slog.NewJSONHandler(sl.output, &slog.HandlerOptions{
-		Level:     level.ToSlogLevel(),
+		Level:     LevelDebug.ToSlogLevel(),
 		AddSource: false,
 	})
 	sl.slogger = slog.New(handler)
 }
 
-// IsEnabled checks if a log level is enabled
+// IsEnabled checks if a log level is enabled against the global minLevel. It
+// does not consult per-component overrides set via SetComponentLevel, since
+// that requires knowing which component is asking; use LogWithLevel (or
+// Debug/Info/Warn/Error, which call it) for component-aware filtering.
 func (sl *StructuredLogger) IsEnabled(level LogLevel) bool {
 	return level >= sl.minLevel
 }
 
+// isEnabledForComponent checks if level is enabled for this logger's current
+// component, consulting componentLevels first and falling back to the global
+// minLevel when the component has no override.
+func (sl *StructuredLogger) isEnabledForComponent(level LogLevel) bool {
+	if sl.component != "" {
+		if override, ok := sl.componentLevels[sl.component]; ok {
+			return level >= override
+		}
+	}
+	return level >= sl.minLevel
+}
+
+// SetComponentLevel sets a minimum logging level for a specific component,
+// overriding the global minLevel for log calls made through a logger whose
+// component (set via WithComponent) matches. A component without an
+// override falls back to the global minLevel, preserving the original
+// behavior. The override applies across every logger derived from this one,
+// regardless of which one SetComponentLevel is called on.
+func (sl *StructuredLogger) SetComponentLevel(component string, level LogLevel) {
+	sl.componentLevels[component] = level
+}
+
 // LogProcessingStart logs the start of record processing
 func LogProcessingStart(logger Logger, recordCount int) {
 	logger.WithProcessingState("start").Info("Starting record processing",
@@ -336,6 +378,25 @@ func LogCalculationResult(logger Logger, totalFare interface{}, recordCount int)
 	)
 }
 
+// LogCalculationBreakdown logs the full fare breakdown (base, standard,
+// extended, and total fare amounts, plus the distance they were computed
+// from) as structured fields, for auditing individual fare computations in
+// more detail than LogCalculationResult's total-only summary. Component
+// amounts are accepted as interface{}, the same as LogCalculationResult's
+// totalFare, so callers can pass a decimal.Decimal (or its string form)
+// without this leaf package taking a dependency on farecalculator.
+func LogCalculationBreakdown(logger Logger, baseFare, standardFare, extendedFare, totalFare, distance interface{}, recordCount int) {
+	logger.WithProcessingState("calculation_complete").Info("Fare calculation breakdown",
+		"base_fare_amount", baseFare,
+		"standard_fare_amount", standardFare,
+		"extended_fare_amount", extendedFare,
+		"total_fare", totalFare,
+		"distance", distance,
+		"record_count", recordCount,
+		"operation", "calculate_fare",
+	)
+}
+
 // Performance optimizations and utilities
 
 // BufferedLogger wraps a logger with buffering for high-performance scenarios
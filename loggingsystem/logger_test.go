@@ -358,6 +358,41 @@ func TestStructuredLogger_IsEnabled(t *testing.T) {
 	}
 }
 
+func TestStructuredLogger_SetComponentLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLoggerWithOptions(&buf, LevelWarn).(*StructuredLogger)
+	logger.SetComponentLevel("parser", LevelDebug)
+	logger.SetComponentLevel("calculator", LevelError)
+
+	parserLogger := logger.WithComponent("parser")
+	calculatorLogger := logger.WithComponent("calculator")
+	otherLogger := logger.WithComponent("other")
+
+	buf.Reset()
+	parserLogger.Debug("parser debug message")
+	if buf.String() == "" {
+		t.Error("Expected parser's DEBUG override to allow a debug message through")
+	}
+
+	buf.Reset()
+	calculatorLogger.Warn("calculator warn message")
+	if buf.String() != "" {
+		t.Error("Expected calculator's ERROR override to suppress a warn message")
+	}
+
+	buf.Reset()
+	calculatorLogger.Error("calculator error message")
+	if buf.String() == "" {
+		t.Error("Expected calculator's ERROR override to allow an error message through")
+	}
+
+	buf.Reset()
+	otherLogger.Info("other info message")
+	if buf.String() != "" {
+		t.Error("Expected a component with no override to fall back to the global WARN level")
+	}
+}
+
 func TestLogProcessingStart(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewLoggerWithOptions(&buf, LevelDebug)
@@ -524,6 +559,43 @@ func TestLogCalculationResult(t *testing.T) {
 	}
 }
 
+func TestLogCalculationBreakdown(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLoggerWithOptions(&buf, LevelDebug)
+
+	LogCalculationBreakdown(logger, "400", "440", "0", "840", "8000", 12)
+
+	output := buf.String()
+	if output == "" {
+		t.Error("Expected output for calculation breakdown log")
+		return
+	}
+
+	var logData map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &logData); err != nil {
+		t.Errorf("Expected valid JSON output, got error: %v", err)
+		return
+	}
+
+	expectedFields := map[string]interface{}{
+		"processing_state":     "calculation_complete",
+		"base_fare_amount":     "400",
+		"standard_fare_amount": "440",
+		"extended_fare_amount": "0",
+		"total_fare":           "840",
+		"distance":             "8000",
+		"record_count":         float64(12),
+		"operation":            "calculate_fare",
+		"level":                "INFO",
+	}
+
+	for key, expectedValue := range expectedFields {
+		if logData[key] != expectedValue {
+			t.Errorf("Expected %s=%v, got %v", key, expectedValue, logData[key])
+		}
+	}
+}
+
 func TestParseLogLevel(t *testing.T) {
 	tests := []struct {
 		levelStr string
@@ -0,0 +1,148 @@
+package loggingsystem
+
+import "time"
+
+// TapLoggerCallback receives a fully-resolved LogEntry for every log call a
+// TapLogger forwards at a level its IsEnabled reports as enabled, including
+// the component/record_id/processing_state context accumulated through the
+// With* chain.
+type TapLoggerCallback func(entry LogEntry)
+
+// TapLogger decorates a Logger, forwarding every call unchanged while also
+// invoking a registered callback with the resolved LogEntry, letting a host
+// application observe log events programmatically instead of parsing the
+// underlying logger's JSON output.
+type TapLogger struct {
+	underlying      Logger
+	callback        TapLoggerCallback
+	baseContext     map[string]interface{}
+	component       string
+	recordID        string
+	processingState string
+}
+
+// NewTapLogger creates a TapLogger that forwards to underlying and invokes
+// callback with each resolved LogEntry
+func NewTapLogger(underlying Logger, callback TapLoggerCallback) Logger {
+	return &TapLogger{
+		underlying:  underlying,
+		callback:    callback,
+		baseContext: make(map[string]interface{}),
+	}
+}
+
+// Debug logs a debug-level message with optional context
+func (tl *TapLogger) Debug(message string, keyValues ...interface{}) {
+	tl.LogWithLevel(LevelDebug, message, keyValues...)
+}
+
+// Info logs an info-level message with optional context
+func (tl *TapLogger) Info(message string, keyValues ...interface{}) {
+	tl.LogWithLevel(LevelInfo, message, keyValues...)
+}
+
+// Warn logs a warning-level message with optional context
+func (tl *TapLogger) Warn(message string, keyValues ...interface{}) {
+	tl.LogWithLevel(LevelWarn, message, keyValues...)
+}
+
+// Error logs an error-level message with optional context
+func (tl *TapLogger) Error(message string, keyValues ...interface{}) {
+	tl.LogWithLevel(LevelError, message, keyValues...)
+}
+
+// LogWithLevel forwards the message to the underlying logger and, if the
+// level is enabled, invokes the callback with the resolved LogEntry
+func (tl *TapLogger) LogWithLevel(level LogLevel, message string, keyValues ...interface{}) {
+	tl.underlying.LogWithLevel(level, message, keyValues...)
+
+	if !tl.IsEnabled(level) || tl.callback == nil {
+		return
+	}
+
+	entryContext := make(map[string]interface{}, len(tl.baseContext)+len(keyValues)/2)
+	for k, v := range tl.baseContext {
+		entryContext[k] = v
+	}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		if key, ok := keyValues[i].(string); ok {
+			entryContext[key] = keyValues[i+1]
+		}
+	}
+
+	tl.callback(LogEntry{
+		Timestamp:       time.Now(),
+		Level:           level.String(),
+		Message:         message,
+		Context:         entryContext,
+		Component:       tl.component,
+		RecordID:        tl.recordID,
+		ProcessingState: tl.processingState,
+	})
+}
+
+// WithContext creates a new logger with additional context
+func (tl *TapLogger) WithContext(context map[string]interface{}) Logger {
+	newContext := make(map[string]interface{}, len(tl.baseContext)+len(context))
+	for k, v := range tl.baseContext {
+		newContext[k] = v
+	}
+	for k, v := range context {
+		newContext[k] = v
+	}
+
+	return &TapLogger{
+		underlying:      tl.underlying.WithContext(context),
+		callback:        tl.callback,
+		baseContext:     newContext,
+		component:       tl.component,
+		recordID:        tl.recordID,
+		processingState: tl.processingState,
+	}
+}
+
+// WithComponent creates a new logger with component identification
+func (tl *TapLogger) WithComponent(component string) Logger {
+	return &TapLogger{
+		underlying:      tl.underlying.WithComponent(component),
+		callback:        tl.callback,
+		baseContext:     tl.baseContext,
+		component:       component,
+		recordID:        tl.recordID,
+		processingState: tl.processingState,
+	}
+}
+
+// WithRecordID creates a new logger with record ID context
+func (tl *TapLogger) WithRecordID(recordID string) Logger {
+	return &TapLogger{
+		underlying:      tl.underlying.WithRecordID(recordID),
+		callback:        tl.callback,
+		baseContext:     tl.baseContext,
+		component:       tl.component,
+		recordID:        recordID,
+		processingState: tl.processingState,
+	}
+}
+
+// WithProcessingState creates a new logger with processing state context
+func (tl *TapLogger) WithProcessingState(state string) Logger {
+	return &TapLogger{
+		underlying:      tl.underlying.WithProcessingState(state),
+		callback:        tl.callback,
+		baseContext:     tl.baseContext,
+		component:       tl.component,
+		recordID:        tl.recordID,
+		processingState: state,
+	}
+}
+
+// SetLevel sets the minimum logging level on the underlying logger
+func (tl *TapLogger) SetLevel(level LogLevel) {
+	tl.underlying.SetLevel(level)
+}
+
+// IsEnabled checks if a log level is enabled on the underlying logger
+func (tl *TapLogger) IsEnabled(level LogLevel) bool {
+	return tl.underlying.IsEnabled(level)
+}
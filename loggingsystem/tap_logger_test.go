@@ -0,0 +1,101 @@
+package loggingsystem
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestNewTapLogger(t *testing.T) {
+	var buf bytes.Buffer
+	underlying := NewLoggerWithOptions(&buf, LevelInfo)
+
+	logger := NewTapLogger(underlying, func(LogEntry) {})
+	if logger == nil {
+		t.Error("Expected non-nil logger")
+	}
+
+	if _, ok := logger.(Logger); !ok {
+		t.Error("TapLogger should implement Logger interface")
+	}
+}
+
+func TestTapLogger_CallbackObservesJSONFields(t *testing.T) {
+	var buf bytes.Buffer
+	underlying := NewLoggerWithOptions(&buf, LevelInfo)
+
+	var observed LogEntry
+	called := false
+	logger := NewTapLogger(underlying, func(entry LogEntry) {
+		called = true
+		observed = entry
+	})
+
+	logger = logger.WithComponent("parser").WithRecordID("rec-42").WithProcessingState("parsing")
+	logger.Info("parsing started", "line_number", 7)
+
+	if !called {
+		t.Fatal("Expected callback to be invoked")
+	}
+
+	var jsonOutput map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &jsonOutput); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+
+	if observed.Message != jsonOutput["msg"] {
+		t.Errorf("Expected callback message %q to match JSON msg %q", observed.Message, jsonOutput["msg"])
+	}
+
+	if observed.Component != jsonOutput["component"] {
+		t.Errorf("Expected callback component %q to match JSON component %q", observed.Component, jsonOutput["component"])
+	}
+
+	if observed.RecordID != jsonOutput["record_id"] {
+		t.Errorf("Expected callback record_id %q to match JSON record_id %q", observed.RecordID, jsonOutput["record_id"])
+	}
+
+	if observed.ProcessingState != jsonOutput["processing_state"] {
+		t.Errorf("Expected callback processing_state %q to match JSON processing_state %q", observed.ProcessingState, jsonOutput["processing_state"])
+	}
+
+	lineNumber, ok := observed.Context["line_number"]
+	if !ok {
+		t.Fatal("Expected callback context to include line_number")
+	}
+	if jsonLineNumber, ok := jsonOutput["line_number"].(float64); !ok || int(jsonLineNumber) != lineNumber {
+		t.Errorf("Expected callback line_number %v to match JSON line_number %v", lineNumber, jsonOutput["line_number"])
+	}
+}
+
+func TestTapLogger_SuppressesCallbackBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	underlying := NewLoggerWithOptions(&buf, LevelWarn)
+
+	called := false
+	logger := NewTapLogger(underlying, func(LogEntry) {
+		called = true
+	})
+
+	logger.Info("should be suppressed")
+
+	if called {
+		t.Error("Expected callback not to be invoked for a level below the minimum")
+	}
+}
+
+func TestTapLogger_DelegatesLevelControl(t *testing.T) {
+	var buf bytes.Buffer
+	underlying := NewLoggerWithOptions(&buf, LevelInfo)
+	logger := NewTapLogger(underlying, nil)
+
+	if !logger.IsEnabled(LevelInfo) {
+		t.Error("Expected LevelInfo to be enabled by default")
+	}
+
+	logger.SetLevel(LevelError)
+
+	if logger.IsEnabled(LevelInfo) {
+		t.Error("Expected LevelInfo to be disabled after SetLevel(LevelError)")
+	}
+}
@@ -3,6 +3,9 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
@@ -27,39 +30,167 @@ type Application struct {
 	formatter    outputformatter.OutputFormatter
 	ctx          context.Context
 	cancel       context.CancelFunc
+
+	// metricsPath, when set, receives Prometheus-style metrics after processing completes
+	metricsPath string
+
+	// minRecordsForFare is the minimum number of valid records required
+	// before a fare is calculated; fewer records are treated as
+	// insufficient data. Defaults to 1, preserving the historical
+	// behavior of returning a zero fare for a single-record trip.
+	minRecordsForFare int
+
+	// MaxErrors caps the number of entries runWithContext accumulates in
+	// processingErrors before aborting, so a pathologically bad input file
+	// can't grow that slice without bound. Once the count reaches MaxErrors,
+	// processing stops collecting further errors and aborts with the format
+	// error exit code. The zero value (the default) disables the limit.
+	MaxErrors int
+
+	// StreamingMode switches Run to a path that never buffers records,
+	// using a farecalculator.FareAccumulator and running aggregates instead
+	// of a []models.DistanceRecord slice, for O(1) memory on truly
+	// unbounded streams. It produces the same fare as the default buffered
+	// path, but the per-record table is skipped (it needs every record),
+	// and so is ValidateSequence (it needs the whole sequence to check
+	// ordering and speed-outlier constraints) — only per-record validation
+	// still runs. Defaults to false, preserving the original buffered path.
+	StreamingMode bool
+
+	// InputPath, when set, makes runWithContext/runStreamingWithContext read
+	// from the named file instead of os.Stdin. Required for FollowMode,
+	// since stdin has no stable file to poll for appended data.
+	InputPath string
+
+	// FollowMode, when true, makes processing poll InputPath for newly
+	// appended lines after reaching its current end, like `tail -f`,
+	// instead of treating EOF as the end of the stream. Each new batch of
+	// lines is fed through the normal parse/validate pipeline as it
+	// arrives, so StreamingMode's running fare updates incrementally.
+	// Polling stops, and the reader reports io.EOF, once ctx is cancelled
+	// (e.g. by SIGINT). Requires InputPath to be set; has no effect
+	// otherwise. Defaults to false, preserving the original read-once
+	// behavior.
+	FollowMode bool
+
+	// WarningWriter receives structured WARN logs for validation issues
+	// marked datavalidator.SeverityWarning, kept separate from errors (which
+	// still go through errorHandler/reportError) so operators can filter the
+	// two streams independently. A SeverityWarning issue does not abort
+	// processing or count against MaxErrors; it is logged and skipped past
+	// like a successfully validated record. Defaults to os.Stderr.
+	WarningWriter io.Writer
+
+	// Timeout, when non-zero, bounds the whole of Run by a
+	// context.WithTimeout derived from the signal-handling context:
+	// processing aborts with errorhandler.ErrProcessingTimeout if it
+	// hasn't finished within Timeout. Defaults to zero (disabled),
+	// preserving the original unbounded behavior. Has no effect on
+	// RunNoSignals, which uses the externally supplied ctx as-is.
+	Timeout time.Duration
+
+	// OutputCloser, when set, is closed by Cleanup after processing
+	// finishes. This is the hook for formatters writing through a
+	// compressing io.WriteCloser such as outputformatter.NewGzipWriter:
+	// gzip only writes its footer on Close, so without this the last
+	// bytes of a gzipped result file would never be flushed. Defaults to
+	// nil, which makes Cleanup a no-op here as before.
+	OutputCloser io.Closer
+}
+
+// warnLogger returns a Logger writing to app.WarningWriter (os.Stderr if
+// unset), used to report SeverityWarning validation issues separately from
+// app.logger.
+func (app *Application) warnLogger() loggingsystem.Logger {
+	writer := app.WarningWriter
+	if writer == nil {
+		writer = os.Stderr
+	}
+	return loggingsystem.NewLoggerWithOptions(writer, loggingsystem.LevelWarn).WithComponent("validator")
+}
+
+// reportValidationWarning reports a SeverityWarning validation issue to
+// app.warnLogger and returns true. It returns false, doing nothing, for any
+// other error (including a non-warning *datavalidator.ValidationError),
+// leaving it to the caller's normal error handling.
+func (app *Application) reportValidationWarning(err error, recordIndex int) bool {
+	ve, ok := err.(*datavalidator.ValidationError)
+	if !ok || ve.Severity != datavalidator.SeverityWarning {
+		return false
+	}
+	app.warnLogger().Warn("Validation warning",
+		"record_index", recordIndex,
+		"validation_type", ve.Type.String(),
+		"error", ve.Error(),
+	)
+	return true
 }
 
 // NewApplication creates and initializes a new Application instance
 func NewApplication() *Application {
+	return NewApplicationWith(Dependencies{})
+}
+
+// Dependencies holds the collaborators NewApplicationWith wires into an
+// Application. Any field left nil is defaulted to the standard
+// implementation, so callers only need to set the fields they want to
+// substitute (e.g. a fake calculator in a test) rather than constructing
+// every collaborator themselves.
+type Dependencies struct {
+	Logger       loggingsystem.Logger
+	ErrorHandler errorhandler.ErrorHandler
+	Parser       inputparser.Parser
+	Validator    datavalidator.Validator
+	Calculator   farecalculator.Calculator
+	Formatter    outputformatter.OutputFormatter
+}
+
+// NewApplicationWith creates an Application from deps, defaulting any nil
+// field to the standard implementation used by NewApplication. This allows
+// substituting a fake parser/validator/calculator/formatter/logger/error
+// handler for unit testing Run's orchestration without real stdin.
+func NewApplicationWith(deps Dependencies) *Application {
 	ctx, cancel := context.WithCancel(context.Background())
-	
-	logger := loggingsystem.NewLogger()
-	errorHandler := errorhandler.NewErrorHandler()
-	parser := inputparser.NewParser()
-	validator := datavalidator.NewValidator()
-	calculator := farecalculator.NewCalculator()
-	formatter := outputformatter.NewFormatter()
+
+	if deps.Logger == nil {
+		deps.Logger = loggingsystem.NewLogger()
+	}
+	if deps.ErrorHandler == nil {
+		deps.ErrorHandler = errorhandler.NewErrorHandler()
+	}
+	if deps.Parser == nil {
+		deps.Parser = inputparser.NewParser()
+	}
+	if deps.Validator == nil {
+		deps.Validator = datavalidator.NewValidator()
+	}
+	if deps.Calculator == nil {
+		deps.Calculator = farecalculator.NewCalculator()
+	}
+	if deps.Formatter == nil {
+		deps.Formatter = outputformatter.NewFormatter()
+	}
 
 	return &Application{
-		logger:       logger,
-		errorHandler: errorHandler,
-		parser:       parser,
-		validator:    validator,
-		calculator:   calculator,
-		formatter:    formatter,
-		ctx:          ctx,
-		cancel:       cancel,
+		logger:            deps.Logger,
+		errorHandler:      deps.ErrorHandler,
+		parser:            deps.Parser,
+		validator:         deps.Validator,
+		calculator:        deps.Calculator,
+		formatter:         deps.Formatter,
+		ctx:               ctx,
+		cancel:            cancel,
+		minRecordsForFare: 1,
 	}
 }
 
-// Run executes the main application processing loop
+// Run executes the main application processing loop for CLI use, installing
+// a signal handler that cancels app.ctx on SIGINT/SIGTERM.
 func (app *Application) Run() error {
-	startTime := time.Now()
-	
 	// Setup signal handling for graceful shutdown
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
-	
+
 	go func() {
 		sig := <-signalChan
 		app.logger.WithComponent("main").Info("Received shutdown signal",
@@ -67,59 +198,110 @@ func (app *Application) Run() error {
 		)
 		app.cancel()
 	}()
-	
+
+	ctx := app.ctx
+	if app.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, app.Timeout)
+		defer cancel()
+	}
+
+	return app.runWithContext(ctx)
+}
+
+// RunNoSignals executes the main application processing loop using ctx, an
+// externally managed context, without installing a signal handler. Use this
+// when Application is embedded in a larger process that already owns
+// SIGINT/SIGTERM handling and controls cancellation itself.
+func (app *Application) RunNoSignals(ctx context.Context) error {
+	return app.runWithContext(ctx)
+}
+
+// runWithContext holds the processing pipeline shared by Run and
+// RunNoSignals; ctx governs cancellation for both.
+func (app *Application) runWithContext(ctx context.Context) error {
+	if app.StreamingMode {
+		return app.runStreamingWithContext(ctx)
+	}
+
+	startTime := time.Now()
+
 	app.logger.WithComponent("main").Info("Starting taxi fare calculation processing")
 	loggingsystem.LogProcessingStart(app.logger.WithComponent("main"), 0)
-	
-	// Parse input records from stdin
-	parseResultChan, err := app.parser.ParseStream(app.ctx, os.Stdin)
+
+	// Parse input records from stdin (or InputPath, optionally following it)
+	input, closeInput, err := app.resolveInput(ctx)
+	if err != nil {
+		app.reportError(err)
+		return err
+	}
+	defer closeInput()
+
+	parseResultChan, err := app.parser.ParseStream(ctx, input)
 	if err != nil {
 		app.logger.WithComponent("parser").Error("Failed to start parsing stream", "error", err.Error())
-		app.errorHandler.HandleError(err)
+		app.reportError(err)
 		return err
 	}
-	
+
 	var records []models.DistanceRecord
 	var processingErrors []error
 	recordCount := 0
-	
+	skippedCount := 0
+
 	// Process records from the input stream
 	for {
 		select {
-		case <-app.ctx.Done():
-			app.logger.WithComponent("main").Info("Processing cancelled by user")
-			return app.ctx.Err()
-			
+		case <-ctx.Done():
+			if app.FollowMode && ctx.Err() != context.DeadlineExceeded {
+				app.logger.WithComponent("main").Info("Follow mode stopped by user, processing records collected so far")
+				goto ProcessComplete
+			}
+			return app.cancellationError(ctx)
+
 		case parseResult, ok := <-parseResultChan:
 			if !ok {
 				// Channel closed, processing complete
 				goto ProcessComplete
 			}
-			
+
 			recordCount++
-			
+
 			// Check for parsing error
 			if parseResult.Error != nil {
-				loggingsystem.LogParsingError(app.logger.WithComponent("parser"), 
+				loggingsystem.LogParsingError(app.logger.WithComponent("parser"),
 					parseResult.Line, "parsing_error", parseResult.Error.Error())
 				processingErrors = append(processingErrors, parseResult.Error)
-				
+
 				// Handle critical parsing errors
 				if app.isCriticalError(parseResult.Error) {
-					app.errorHandler.HandleError(parseResult.Error)
+					app.reportError(parseResult.Error)
 					return parseResult.Error
 				}
+				if limitErr := app.errorLimitHit(processingErrors); limitErr != nil {
+					app.reportError(limitErr)
+					return limitErr
+				}
 				continue
 			}
-			
+
 			// Validate individual record
 			if err := app.validator.ValidateRecord(parseResult.Record); err != nil {
-				loggingsystem.LogValidationError(app.logger.WithComponent("validator"), 
+				if app.reportValidationWarning(err, recordCount-1) {
+					records = append(records, parseResult.Record)
+					continue
+				}
+				loggingsystem.LogValidationError(app.logger.WithComponent("validator"),
 					recordCount-1, "record_validation", err.Error())
 				processingErrors = append(processingErrors, err)
+				skippedCount++
+				if limitErr := app.errorLimitHit(processingErrors); limitErr != nil {
+					app.reportError(limitErr)
+					return limitErr
+				}
 				continue
 			}
-			
+
 			records = append(records, parseResult.Record)
 		}
 	}
@@ -136,17 +318,34 @@ ProcessComplete:
 	// Validate the complete sequence of records
 	if len(records) == 0 {
 		err := errors.New("insufficient data: no valid records processed")
-		app.errorHandler.HandleError(err)
+		app.reportDiagnostics(app.buildDiagnostics(processingErrors))
+		app.reportError(err)
 		return err
 	}
-	
-	if err := app.validator.ValidateSequence(records); err != nil {
-		loggingsystem.LogValidationError(app.logger.WithComponent("validator"), 
-			-1, "sequence_validation", err.Error())
-		app.errorHandler.HandleError(err)
+
+	if len(records) < app.minRecordsForFare {
+		err := errors.New("insufficient data: fewer valid records than minRecordsForFare, no distance travelled")
+		app.reportDiagnostics(app.buildDiagnostics(processingErrors))
+		app.reportError(err)
 		return err
 	}
-	
+
+	if err := app.validator.ValidateSequence(records); err != nil {
+		if !app.reportValidationWarning(err, -1) {
+			loggingsystem.LogValidationError(app.logger.WithComponent("validator"),
+				-1, "sequence_validation", err.Error())
+			app.reportError(err)
+			return err
+		}
+	}
+
+	// If the validator reordered records to validate them (e.g.
+	// PreSortByTimestamp), calculate and report on that same order rather
+	// than the original, possibly out-of-order input.
+	if sorter, ok := app.validator.(datavalidator.SequenceSorter); ok {
+		records = sorter.SortSequence(records)
+	}
+
 	// Calculate fare from processed records
 	calculation := app.calculator.CalculateFromRecords(records)
 	
@@ -164,16 +363,268 @@ ProcessComplete:
 	// Format and display the result
 	if err := app.formatter.FormatProcessingResult(result); err != nil {
 		app.logger.WithComponent("formatter").Error("Output formatting failed", "error", err.Error())
-		app.errorHandler.HandleError(err)
+		app.reportError(err)
 		return err
 	}
 	
-	loggingsystem.LogProcessingComplete(app.logger.WithComponent("main"), 
+	loggingsystem.LogProcessingComplete(app.logger.WithComponent("main"),
 		len(records), processingTime)
-	
+
+	// Write Prometheus-style metrics if requested
+	if app.metricsPath != "" {
+		metrics := Metrics{
+			RecordsProcessed: len(records),
+			RecordsSkipped:   skippedCount,
+			ErrorsTotal:      len(processingErrors),
+			DurationSeconds:  processingTime.Seconds(),
+			TotalFare:        calculation.TotalFare.InexactFloat64(),
+		}
+		if err := writeMetricsFile(app.metricsPath, metrics); err != nil {
+			app.logger.WithComponent("main").Error("Failed to write metrics file", "error", err.Error())
+		}
+	}
+
 	return nil
 }
 
+// runStreamingWithContext is StreamingMode's processing path: it folds each
+// valid record into a farecalculator.FareAccumulator instead of buffering a
+// []models.DistanceRecord slice, so memory use stays O(1) regardless of
+// input size. It otherwise mirrors runWithContext's parse/validate loop,
+// except ValidateSequence is skipped (it needs the full buffered sequence)
+// and the result is reported via FormatCurrentFare rather than
+// FormatProcessingResult, since there is no per-record table to show.
+func (app *Application) runStreamingWithContext(ctx context.Context) error {
+	startTime := time.Now()
+
+	app.logger.WithComponent("main").Info("Starting taxi fare calculation processing (streaming mode)")
+	loggingsystem.LogProcessingStart(app.logger.WithComponent("main"), 0)
+
+	input, closeInput, err := app.resolveInput(ctx)
+	if err != nil {
+		app.reportError(err)
+		return err
+	}
+	defer closeInput()
+
+	parseResultChan, err := app.parser.ParseStream(ctx, input)
+	if err != nil {
+		app.logger.WithComponent("parser").Error("Failed to start parsing stream", "error", err.Error())
+		app.reportError(err)
+		return err
+	}
+
+	accumulator := farecalculator.NewFareAccumulator(app.calculator)
+	var processingErrors []error
+	recordCount := 0
+	skippedCount := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			if app.FollowMode && ctx.Err() != context.DeadlineExceeded {
+				app.logger.WithComponent("main").Info("Follow mode stopped by user, reporting fare accumulated so far")
+				goto StreamingProcessComplete
+			}
+			return app.cancellationError(ctx)
+
+		case parseResult, ok := <-parseResultChan:
+			if !ok {
+				goto StreamingProcessComplete
+			}
+
+			recordCount++
+
+			if parseResult.Error != nil {
+				loggingsystem.LogParsingError(app.logger.WithComponent("parser"),
+					parseResult.Line, "parsing_error", parseResult.Error.Error())
+				processingErrors = append(processingErrors, parseResult.Error)
+
+				if app.isCriticalError(parseResult.Error) {
+					app.reportError(parseResult.Error)
+					return parseResult.Error
+				}
+				if limitErr := app.errorLimitHit(processingErrors); limitErr != nil {
+					app.reportError(limitErr)
+					return limitErr
+				}
+				continue
+			}
+
+			if err := app.validator.ValidateRecord(parseResult.Record); err != nil {
+				if !app.reportValidationWarning(err, recordCount-1) {
+					loggingsystem.LogValidationError(app.logger.WithComponent("validator"),
+						recordCount-1, "record_validation", err.Error())
+					processingErrors = append(processingErrors, err)
+					skippedCount++
+					if limitErr := app.errorLimitHit(processingErrors); limitErr != nil {
+						app.reportError(limitErr)
+						return limitErr
+					}
+					continue
+				}
+			}
+
+			accumulator.Add(parseResult.Record)
+
+			// In follow mode there is no final EOF to report against, so
+			// print the running fare as it updates with each new record
+			// instead of only once at the end.
+			if app.FollowMode && accumulator.Count() >= app.minRecordsForFare {
+				if err := app.formatter.FormatCurrentFare(accumulator.Result()); err != nil {
+					app.logger.WithComponent("formatter").Error("Output formatting failed", "error", err.Error())
+				}
+			}
+		}
+	}
+
+StreamingProcessComplete:
+	processingTime := time.Since(startTime)
+
+	app.logger.WithComponent("main").Info("Input processing completed",
+		"total_records", accumulator.Count(),
+		"processing_errors", len(processingErrors),
+		"processing_time_ms", processingTime.Milliseconds(),
+	)
+
+	if accumulator.Count() == 0 {
+		err := errors.New("insufficient data: no valid records processed")
+		app.reportDiagnostics(app.buildDiagnostics(processingErrors))
+		app.reportError(err)
+		return err
+	}
+
+	if accumulator.Count() < app.minRecordsForFare {
+		err := errors.New("insufficient data: fewer valid records than minRecordsForFare, no distance travelled")
+		app.reportDiagnostics(app.buildDiagnostics(processingErrors))
+		app.reportError(err)
+		return err
+	}
+
+	calculation := accumulator.Result()
+
+	loggingsystem.LogCalculationResult(app.logger.WithComponent("calculator"),
+		calculation.TotalFare, accumulator.Count())
+
+	if err := app.formatter.FormatCurrentFare(calculation); err != nil {
+		app.logger.WithComponent("formatter").Error("Output formatting failed", "error", err.Error())
+		app.reportError(err)
+		return err
+	}
+
+	loggingsystem.LogProcessingComplete(app.logger.WithComponent("main"),
+		accumulator.Count(), processingTime)
+
+	if app.metricsPath != "" {
+		metrics := Metrics{
+			RecordsProcessed: accumulator.Count(),
+			RecordsSkipped:   skippedCount,
+			ErrorsTotal:      len(processingErrors),
+			DurationSeconds:  processingTime.Seconds(),
+			TotalFare:        calculation.TotalFare.InexactFloat64(),
+		}
+		if err := writeMetricsFile(app.metricsPath, metrics); err != nil {
+			app.logger.WithComponent("main").Error("Failed to write metrics file", "error", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// cancellationError logs and returns the error to report for a cancelled
+// ctx: errorhandler.ErrProcessingTimeout when Timeout elapsed, or ctx.Err()
+// itself (context.Canceled) for a user-initiated cancellation, preserving
+// the original "cancelled by user" message in that case.
+func (app *Application) cancellationError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		app.logger.WithComponent("main").Error("Processing timed out", "timeout", app.Timeout)
+		return errorhandler.ErrProcessingTimeout
+	}
+	app.logger.WithComponent("main").Info("Processing cancelled by user")
+	return ctx.Err()
+}
+
+// resolveInput returns the reader that runWithContext/runStreamingWithContext
+// should parse, and a close func the caller must invoke once done with it (a
+// no-op when reading from stdin). With InputPath unset, it returns os.Stdin
+// unchanged. With InputPath set, it opens that file; if FollowMode is also
+// enabled, the returned reader polls for newly appended data at EOF instead
+// of ending the stream, like `tail -f`, until ctx is cancelled.
+func (app *Application) resolveInput(ctx context.Context) (io.Reader, func(), error) {
+	if app.InputPath == "" {
+		return os.Stdin, func() {}, nil
+	}
+
+	file, err := os.Open(app.InputPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+
+	if app.FollowMode {
+		return newFollowReader(ctx, file), func() { file.Close() }, nil
+	}
+	return file, func() { file.Close() }, nil
+}
+
+// reportError emits a structured error body through app.formatter, when it
+// supports one, before handing err to app.errorHandler. This lets a caller
+// piping stdout (e.g. with a JSON formatter selected) recover structured
+// failure details even on the error path, since app.errorHandler.HandleError
+// exits the process once it returns.
+func (app *Application) reportError(err error) {
+	if ef, ok := app.formatter.(outputformatter.ErrorFormatter); ok {
+		if formatErr := ef.FormatError(err); formatErr != nil {
+			app.logger.WithComponent("formatter").Error("Failed to format error output", "error", formatErr.Error())
+		}
+	}
+	app.errorHandler.HandleError(err)
+}
+
+// reportDiagnostics emits diag through app.formatter, when it supports one,
+// so an insufficient-data failure comes with actionable line/error-type
+// counts instead of just the bare error message reportError already prints.
+// A formatting failure here is logged but never escalated, matching
+// reportError's treatment of its own FormatError failures.
+func (app *Application) reportDiagnostics(diag models.ProcessingDiagnostics) {
+	df, ok := app.formatter.(outputformatter.DiagnosticFormatter)
+	if !ok {
+		return
+	}
+	if err := df.FormatDiagnostics(diag); err != nil {
+		app.logger.WithComponent("formatter").Error("Failed to format diagnostics output", "error", err.Error())
+	}
+}
+
+// buildDiagnostics summarizes processingErrors (the per-line/record errors
+// collected during the parse/validate loop) together with app.parser's own
+// line-level stats, for reportDiagnostics to print on an insufficient-data
+// failure. Parser stats are only available when app.parser is a
+// *inputparser.StreamParser; other Parser implementations (e.g. JSONParser)
+// leave TotalLines/BlankLines zero.
+func (app *Application) buildDiagnostics(processingErrors []error) models.ProcessingDiagnostics {
+	diag := models.ProcessingDiagnostics{
+		ParseErrorsByType:      make(map[string]int),
+		ValidationErrorsByType: make(map[string]int),
+	}
+
+	if sp, ok := app.parser.(*inputparser.StreamParser); ok {
+		stats := sp.Stats()
+		diag.TotalLines = stats.TotalLines
+		diag.BlankLines = stats.BlankLines
+	}
+
+	for _, err := range processingErrors {
+		switch e := err.(type) {
+		case *inputparser.ParsingError:
+			diag.ParseErrorsByType[e.Type.String()]++
+		case *datavalidator.ValidationError:
+			diag.ValidationErrorsByType[e.Type.String()]++
+		}
+	}
+
+	return diag
+}
+
 // isCriticalError determines if an error should stop processing
 func (app *Application) isCriticalError(err error) bool {
 	switch err.(type) {
@@ -189,6 +640,20 @@ func (app *Application) isCriticalError(err error) bool {
 	}
 }
 
+// errorLimitHit returns a non-nil abort error once processingErrors has
+// reached app.MaxErrors, so runWithContext can stop collecting further
+// errors for a pathologically bad input file instead of growing
+// processingErrors without bound. The zero value of MaxErrors (the default)
+// disables the limit, so this always returns nil in that case.
+func (app *Application) errorLimitHit(processingErrors []error) error {
+	if app.MaxErrors <= 0 || len(processingErrors) < app.MaxErrors {
+		return nil
+	}
+	app.logger.WithComponent("main").Error("Error limit hit, aborting",
+		"max_errors", app.MaxErrors, "errors_seen", len(processingErrors))
+	return fmt.Errorf("invalid input: exceeded maximum error limit of %d", app.MaxErrors)
+}
+
 // Cleanup performs graceful cleanup of application resources
 func (app *Application) Cleanup() {
 	app.logger.WithComponent("main").Info("Performing application cleanup")
@@ -196,13 +661,52 @@ func (app *Application) Cleanup() {
 	if app.cancel != nil {
 		app.cancel()
 	}
-	
-	// Additional cleanup logic could go here
-	// For example: closing database connections, flushing buffers, etc.
+
+	if app.OutputCloser != nil {
+		if err := app.OutputCloser.Close(); err != nil {
+			app.logger.WithComponent("main").Error("Failed to close output", "error", err.Error())
+		}
+	}
 }
 
 func main() {
+	metricsPath := flag.String("metrics", "", "write Prometheus-style metrics to this path after processing completes")
+	startLine := flag.Int("start-line", 0, "skip the first N lines of input, resuming from a checkpoint line number")
+	silent := flag.Bool("silent", false, "suppress all output on success (logs raised to ERROR, formatter discarded); errors still print and set the exit code")
+	minRecordsForFare := flag.Int("min-records-for-fare", 1, "minimum number of valid records required to calculate a fare; fewer is treated as insufficient data (set 2 to reject single-record trips)")
+	maxErrors := flag.Int("max-errors", 0, "abort once this many processing errors have been seen; 0 (default) disables the limit")
+	printSchema := flag.Bool("print-schema", false, "print the JSON Schema (draft-07) for the JSON formatter's processing-result output and exit, without reading input")
+	streamingMode := flag.Bool("streaming", false, "never buffer records in memory; computes the same fare with O(1) memory but skips the per-record table and sequence-level validation")
+	inputPath := flag.String("input", "", "read from this file instead of stdin; required for -follow")
+	followMode := flag.Bool("follow", false, "after reaching the end of -input, poll for newly appended lines like `tail -f` instead of finishing; stops cleanly on SIGINT")
+	timeout := flag.Duration("timeout", 0, "abort processing with a general error if it hasn't finished within this duration; 0 (default) disables the limit")
+	flag.Parse()
+
+	if *printSchema {
+		fmt.Println(outputformatter.NewJSONFormatter().(*outputformatter.JSONFormatter).JSONSchema())
+		return
+	}
+
+	if *followMode && *inputPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -follow requires -input to name a file to tail")
+		os.Exit(1)
+	}
+
 	app := NewApplication()
+	app.metricsPath = *metricsPath
+	app.minRecordsForFare = *minRecordsForFare
+	app.MaxErrors = *maxErrors
+	app.StreamingMode = *streamingMode
+	app.InputPath = *inputPath
+	app.FollowMode = *followMode
+	app.Timeout = *timeout
+	if parser, ok := app.parser.(*inputparser.StreamParser); ok {
+		parser.StartLine = *startLine
+	}
+	if *silent {
+		app.logger.SetLevel(loggingsystem.LevelError)
+		app.formatter = outputformatter.NewNoopFormatter()
+	}
 	defer app.Cleanup()
 	
 	// Run the application
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// followReader wraps an already-open file so that reaching its current end
+// polls for newly appended data instead of returning io.EOF, the way `tail
+// -f` behaves. Polling stops, and Read finally reports io.EOF, once ctx is
+// cancelled, so a bufio.Reader built on top of a followReader (as
+// inputparser.StreamParser.ParseStream uses) exits cleanly on shutdown
+// instead of blocking forever.
+type followReader struct {
+	ctx          context.Context
+	file         *os.File
+	pollInterval time.Duration
+}
+
+// newFollowReader creates a followReader over file, polling every 200ms for
+// newly appended data until ctx is cancelled.
+func newFollowReader(ctx context.Context, file *os.File) *followReader {
+	return &followReader{
+		ctx:          ctx,
+		file:         file,
+		pollInterval: 200 * time.Millisecond,
+	}
+}
+
+// Read implements io.Reader, blocking and polling at EOF rather than
+// reporting it, until ctx is cancelled.
+func (fr *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := fr.file.Read(p)
+		if n > 0 {
+			return n, nil
+		}
+		if err != nil && err != io.EOF {
+			return n, err
+		}
+
+		select {
+		case <-fr.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(fr.pollInterval):
+			// Loop back and try reading again; new data may have arrived.
+		}
+	}
+}
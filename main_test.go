@@ -3,13 +3,44 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/datavalidator"
+	"golang-taxi-fare/errorhandler"
+	"golang-taxi-fare/farecalculator"
+	"golang-taxi-fare/loggingsystem"
+	"golang-taxi-fare/models"
+	"golang-taxi-fare/outputformatter"
 )
 
+// syncBuffer is a bytes.Buffer guarded by a mutex, for tests that read a
+// formatter's output from the main goroutine while the application writes to
+// it from a background goroutine (e.g. follow mode).
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.String()
+}
+
 func TestNewApplication(t *testing.T) {
 	app := NewApplication()
 	
@@ -65,6 +96,27 @@ func TestApplicationCleanup(t *testing.T) {
 	}
 }
 
+func TestApplicationCleanup_ClosesOutputCloser(t *testing.T) {
+	app := NewApplication()
+	closer := &fakeCloser{}
+	app.OutputCloser = closer
+
+	app.Cleanup()
+
+	if !closer.closed {
+		t.Error("Expected Cleanup() to close app.OutputCloser")
+	}
+}
+
+type fakeCloser struct {
+	closed bool
+}
+
+func (f *fakeCloser) Close() error {
+	f.closed = true
+	return nil
+}
+
 func TestIsCriticalError(t *testing.T) {
 	app := NewApplication()
 	
@@ -245,6 +297,448 @@ func TestApplicationWithEmptyInput(t *testing.T) {
 	}
 }
 
+func TestApplicationMinRecordsForFare(t *testing.T) {
+	app := NewApplication()
+	app.minRecordsForFare = 2
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	go func() {
+		defer w.Close()
+		w.Write([]byte("12:34:56.789 12345678.5\n"))
+	}()
+	defer func() {
+		os.Stdin = oldStdin
+	}()
+
+	err := app.Run()
+	if err == nil {
+		t.Fatal("Expected error when fewer than minRecordsForFare records are processed")
+	}
+
+	if !strings.Contains(err.Error(), "insufficient data") {
+		t.Errorf("Expected error to mention insufficient data, got: %v", err)
+	}
+}
+
+// fakeCalculator is a minimal farecalculator.Calculator used to assert that
+// NewApplicationWith actually wires in an injected dependency rather than
+// falling back to the standard implementation.
+type fakeCalculator struct {
+	called bool
+}
+
+func (f *fakeCalculator) CalculateFare(distanceMeters decimal.Decimal) farecalculator.FareBreakdown {
+	return farecalculator.FareBreakdown{}
+}
+
+func (f *fakeCalculator) CalculateFromRecords(records []models.DistanceRecord) models.FareCalculation {
+	f.called = true
+	return models.FareCalculation{TotalFare: decimal.NewFromInt(999)}
+}
+
+func TestNewApplicationWithInjectsFakeCalculator(t *testing.T) {
+	fake := &fakeCalculator{}
+	app := NewApplicationWith(Dependencies{Calculator: fake})
+
+	if app.calculator != fake {
+		t.Fatal("Expected app.calculator to be the injected fake")
+	}
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	go func() {
+		defer w.Close()
+		w.Write([]byte("12:34:56.789 12345678.5\n12:34:57.789 12345679.5\n"))
+	}()
+	defer func() {
+		os.Stdin = oldStdin
+	}()
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run() returned unexpected error: %v", err)
+	}
+
+	if !fake.called {
+		t.Error("Expected Run to invoke the injected fake calculator's CalculateFromRecords")
+	}
+}
+
+func TestNewApplicationWithDefaultsUnsetFields(t *testing.T) {
+	app := NewApplicationWith(Dependencies{})
+
+	if app.logger == nil {
+		t.Error("Expected logger to default to the standard implementation")
+	}
+	if app.errorHandler == nil {
+		t.Error("Expected errorHandler to default to the standard implementation")
+	}
+	if app.parser == nil {
+		t.Error("Expected parser to default to the standard implementation")
+	}
+	if app.validator == nil {
+		t.Error("Expected validator to default to the standard implementation")
+	}
+	if app.calculator == nil {
+		t.Error("Expected calculator to default to the standard implementation")
+	}
+	if app.formatter == nil {
+		t.Error("Expected formatter to default to the standard implementation")
+	}
+}
+
+func TestApplicationStreamingModeMatchesBufferedFare(t *testing.T) {
+	input := "12:34:56.789 12345678.5\n12:34:57.123 12345679.1\n12:34:58.456 12345680.3\n"
+
+	runOnce := func(streaming bool) string {
+		var buf bytes.Buffer
+		app := NewApplicationWith(Dependencies{Formatter: outputformatter.NewJSONFormatterWithOutput(&buf)})
+		app.StreamingMode = streaming
+
+		oldStdin := os.Stdin
+		r, w, _ := os.Pipe()
+		os.Stdin = r
+		go func() {
+			defer w.Close()
+			w.Write([]byte(input))
+		}()
+		defer func() {
+			os.Stdin = oldStdin
+		}()
+
+		if err := app.Run(); err != nil {
+			t.Fatalf("Run() (streaming=%v) returned error: %v", streaming, err)
+		}
+		return buf.String()
+	}
+
+	extractTotalFare := func(output string) string {
+		var payload struct {
+			Calculation struct {
+				TotalFare string `json:"total_fare"`
+			} `json:"calculation"`
+			TotalFare string `json:"total_fare"`
+		}
+		if err := json.Unmarshal([]byte(output), &payload); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\noutput: %s", err, output)
+		}
+		if payload.Calculation.TotalFare != "" {
+			return payload.Calculation.TotalFare
+		}
+		return payload.TotalFare
+	}
+
+	bufferedFare := extractTotalFare(runOnce(false))
+	streamingFare := extractTotalFare(runOnce(true))
+
+	if bufferedFare == "" || streamingFare == "" {
+		t.Fatalf("failed to extract total_fare: buffered=%q streaming=%q", bufferedFare, streamingFare)
+	}
+	if bufferedFare != streamingFare {
+		t.Errorf("streaming fare %s does not match buffered fare %s", streamingFare, bufferedFare)
+	}
+}
+
+// TestApplicationStreamingModeMatchesBufferedFare_OdometerMax is the
+// OdometerMax counterpart to TestApplicationStreamingModeMatchesBufferedFare:
+// with a rollover mid-stream, FareAccumulator (StreamingMode's engine) must
+// unroll it the same way CalculateFromRecords does, not reduce to a raw
+// min/max pair that reinterprets the rollover as a huge jump in distance.
+func TestApplicationStreamingModeMatchesBufferedFare_OdometerMax(t *testing.T) {
+	// 90000000 -> 95000000 is a normal increase; 95000000 -> 10000000 looks
+	// like a decrease but is within the rollover band of OdometerMax, so it
+	// must be read as (OdometerMax-95000000)+10000000 = 15000000m travelled,
+	// not a genuine ~85,000,000m drop.
+	input := "12:34:56.789 90000000.0\n12:34:57.123 95000000.0\n12:34:58.456 10000000.0\n"
+
+	runOnce := func(streaming bool) string {
+		var buf bytes.Buffer
+		validator := datavalidator.NewValidator().(*datavalidator.DataValidator)
+		validator.OdometerMax = decimal.NewFromInt(100000000)
+
+		calculator := farecalculator.NewCalculator().(*farecalculator.TaxiCalculator)
+		calculator.OdometerMax = decimal.NewFromInt(100000000)
+
+		app := NewApplicationWith(Dependencies{
+			Validator:  validator,
+			Calculator: calculator,
+			Formatter:  outputformatter.NewJSONFormatterWithOutput(&buf),
+		})
+		app.StreamingMode = streaming
+
+		oldStdin := os.Stdin
+		r, w, _ := os.Pipe()
+		os.Stdin = r
+		go func() {
+			defer w.Close()
+			w.Write([]byte(input))
+		}()
+		defer func() {
+			os.Stdin = oldStdin
+		}()
+
+		if err := app.Run(); err != nil {
+			t.Fatalf("Run() (streaming=%v) returned error: %v", streaming, err)
+		}
+		return buf.String()
+	}
+
+	extractTotalFare := func(output string) string {
+		var payload struct {
+			Calculation struct {
+				TotalFare string `json:"total_fare"`
+			} `json:"calculation"`
+			TotalFare string `json:"total_fare"`
+		}
+		if err := json.Unmarshal([]byte(output), &payload); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\noutput: %s", err, output)
+		}
+		if payload.Calculation.TotalFare != "" {
+			return payload.Calculation.TotalFare
+		}
+		return payload.TotalFare
+	}
+
+	bufferedFare := extractTotalFare(runOnce(false))
+	streamingFare := extractTotalFare(runOnce(true))
+
+	if bufferedFare == "" || streamingFare == "" {
+		t.Fatalf("failed to extract total_fare: buffered=%q streaming=%q", bufferedFare, streamingFare)
+	}
+	if bufferedFare != streamingFare {
+		t.Errorf("streaming fare %s does not match buffered fare %s", streamingFare, bufferedFare)
+	}
+}
+
+// TestApplicationPreSortByTimestamp exercises the full Application, not just
+// the validator in isolation: with PreSortByTimestamp enabled, a sequence
+// whose last two lines arrive with swapped timestamps must not only pass
+// ValidateSequence, but also have CalculateFromRecords (and everything
+// downstream of it) compute the fare from the chronologically-sorted order,
+// not the original out-of-order input.
+func TestApplicationPreSortByTimestamp(t *testing.T) {
+	// Timestamps 00s,10s,30s,20s (last two swapped); distances track each
+	// record's position in chronological order, so StrategyFirstLast exposes
+	// the difference: sorted, travel is 5000m (last(5000)-first(0)); read in
+	// file order, the last line (distance 3000) makes it look like only
+	// 3000m travelled.
+	input := "00:00:00.000 10000000.0\n" +
+		"00:00:10.000 10002000.0\n" +
+		"00:00:30.000 10005000.0\n" +
+		"00:00:20.000 10003000.0\n"
+
+	runOnce := func(preSort bool) (string, error) {
+		var buf bytes.Buffer
+		validator := datavalidator.NewValidator().(*datavalidator.DataValidator)
+		validator.PreSortByTimestamp = preSort
+
+		calculator := farecalculator.NewCalculator().(*farecalculator.TaxiCalculator)
+		calculator.DistanceStrategy = farecalculator.StrategyFirstLast
+
+		app := NewApplicationWith(Dependencies{
+			Validator:  validator,
+			Calculator: calculator,
+			Formatter:  outputformatter.NewJSONFormatterWithOutput(&buf),
+		})
+		app.errorHandler = errorhandler.NewErrorHandlerWithOptions(false, false)
+
+		oldStdin := os.Stdin
+		r, w, _ := os.Pipe()
+		os.Stdin = r
+		go func() {
+			defer w.Close()
+			w.Write([]byte(input))
+		}()
+		defer func() {
+			os.Stdin = oldStdin
+		}()
+
+		err := app.Run()
+		return buf.String(), err
+	}
+
+	if _, err := runOnce(false); err == nil {
+		t.Fatal("expected ValidateSequence to reject the out-of-order timestamps without PreSortByTimestamp")
+	}
+
+	sortedOutput, err := runOnce(true)
+	if err != nil {
+		t.Fatalf("Run() with PreSortByTimestamp returned error: %v\noutput: %s", err, sortedOutput)
+	}
+
+	var payload struct {
+		Calculation struct {
+			TotalFare string `json:"total_fare"`
+		} `json:"calculation"`
+	}
+	if err := json.Unmarshal([]byte(sortedOutput), &payload); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\noutput: %s", err, sortedOutput)
+	}
+
+	calc := farecalculator.NewCalculator().(*farecalculator.TaxiCalculator)
+	want := calc.CalculateFare(decimal.NewFromInt(5000)).TotalFare // chronologically-sorted first-last delta
+	if payload.Calculation.TotalFare != want.String() {
+		t.Errorf("TotalFare = %s, want %s (fare for the chronologically-sorted 5000m trip); "+
+			"a mismatch means CalculateFromRecords saw the unsorted 3000m order instead",
+			payload.Calculation.TotalFare, want.String())
+	}
+}
+
+func TestApplicationMaxErrors(t *testing.T) {
+	app := NewApplication()
+	app.errorHandler = errorhandler.NewErrorHandlerWithOptions(false, false)
+	app.MaxErrors = 3
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	go func() {
+		defer w.Close()
+		for i := 0; i < 10; i++ {
+			w.Write([]byte("invalid line\n"))
+		}
+	}()
+	defer func() {
+		os.Stdin = oldStdin
+	}()
+
+	err := app.Run()
+	if err == nil {
+		t.Fatal("Expected error when MaxErrors is exceeded")
+	}
+	if !strings.Contains(err.Error(), "exceeded maximum error limit") {
+		t.Errorf("Expected error to mention the error limit, got: %v", err)
+	}
+
+	exitCode := app.errorHandler.HandleError(err)
+	if exitCode != errorhandler.ExitFormatError {
+		t.Errorf("Expected ExitFormatError, got %v", exitCode)
+	}
+}
+
+// warnOnSecondRecordValidator is a minimal datavalidator.Validator that
+// flags the second record it sees as a SeverityWarning issue and otherwise
+// passes every record and the sequence, used to exercise Application's
+// warning-stream routing without depending on a real check that produces one.
+type warnOnSecondRecordValidator struct {
+	seen int
+}
+
+func (v *warnOnSecondRecordValidator) ValidateRecord(record models.DistanceRecord) error {
+	v.seen++
+	if v.seen == 2 {
+		return &datavalidator.ValidationError{
+			Type:     datavalidator.ValidationErrorTypeConstraint,
+			Message:  "suspiciously large jump",
+			Severity: datavalidator.SeverityWarning,
+		}
+	}
+	return nil
+}
+
+func (v *warnOnSecondRecordValidator) ValidateSequence(records []models.DistanceRecord) error {
+	return nil
+}
+
+func TestApplicationWarningWriter(t *testing.T) {
+	app := NewApplicationWith(Dependencies{Validator: &warnOnSecondRecordValidator{}})
+
+	var warnings syncBuffer
+	app.WarningWriter = &warnings
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	go func() {
+		defer w.Close()
+		w.Write([]byte("12:34:56.789 12345678.5\n12:34:57.789 12345679.5\n12:34:58.789 12345680.5\n"))
+	}()
+	defer func() {
+		os.Stdin = oldStdin
+	}()
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run() returned unexpected error: %v", err)
+	}
+
+	if !strings.Contains(warnings.String(), "suspiciously large jump") {
+		t.Errorf("Expected WarningWriter to receive the warning, got: %q", warnings.String())
+	}
+	if !strings.Contains(warnings.String(), "WARN") {
+		t.Errorf("Expected a WARN-level structured log, got: %q", warnings.String())
+	}
+}
+
+func TestApplicationReportErrorWithJSONFormatter(t *testing.T) {
+	app := NewApplication()
+	app.errorHandler = errorhandler.NewErrorHandlerWithOptions(false, false)
+
+	var buf bytes.Buffer
+	app.formatter = outputformatter.NewJSONFormatterWithOutput(&buf)
+
+	err := errors.New("insufficient data: no valid records processed")
+	app.reportError(err)
+
+	if !strings.Contains(buf.String(), `"error":"insufficient data: no valid records processed"`) {
+		t.Errorf("expected JSON error body on stdout, got %q", buf.String())
+	}
+
+	exitCode := app.errorHandler.HandleError(err)
+	if exitCode != errorhandler.ExitInsufficientData {
+		t.Errorf("expected ExitInsufficientData, got %v", exitCode)
+	}
+}
+
+func TestApplicationInsufficientDataDiagnostics(t *testing.T) {
+	app := NewApplication()
+	app.errorHandler = errorhandler.NewErrorHandlerWithOptions(false, false)
+
+	var buf bytes.Buffer
+	app.formatter = outputformatter.NewJSONFormatterWithOutput(&buf)
+
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	go func() {
+		defer w.Close()
+		w.Write([]byte("invalid line\n\nalso invalid\n"))
+	}()
+	defer func() {
+		os.Stdin = oldStdin
+	}()
+
+	err := app.Run()
+	if err == nil {
+		t.Fatal("Expected error for all-invalid input")
+	}
+	if !strings.Contains(err.Error(), "insufficient data") {
+		t.Fatalf("Expected insufficient data error, got: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"total_lines":3`) {
+		t.Errorf("expected diagnostics to report total_lines:3, got %q", output)
+	}
+	if !strings.Contains(output, `"blank_lines":1`) {
+		t.Errorf("expected diagnostics to report blank_lines:1, got %q", output)
+	}
+	if !strings.Contains(output, `"format":2`) {
+		t.Errorf("expected diagnostics to report 2 format parse errors, got %q", output)
+	}
+}
+
+func TestApplicationReportErrorWithConsoleFormatter(t *testing.T) {
+	app := NewApplication()
+	app.errorHandler = errorhandler.NewErrorHandlerWithOptions(false, false)
+
+	// ConsoleFormatter does not implement ErrorFormatter, so reportError
+	// should fall through to the error handler without failing.
+	err := errors.New("malformed input: bad record")
+	app.reportError(err)
+}
+
 func TestApplicationContextCancellation(t *testing.T) {
 	app := NewApplication()
 	
@@ -260,4 +754,221 @@ func TestApplicationContextCancellation(t *testing.T) {
 	if err != context.Canceled {
 		t.Errorf("Expected context.Canceled error, got: %v", err)
 	}
-}
\ No newline at end of file
+}
+
+func TestApplicationTimeout(t *testing.T) {
+	app := NewApplication()
+	app.Timeout = 50 * time.Millisecond
+
+	// Redirect stdin to a pipe that is never written to, simulating an
+	// artificially slow (hanging) reader.
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	defer func() {
+		os.Stdin = oldStdin
+		w.Close()
+	}()
+
+	err := app.Run()
+	if !errors.Is(err, errorhandler.ErrProcessingTimeout) {
+		t.Fatalf("Expected errorhandler.ErrProcessingTimeout, got: %v", err)
+	}
+}
+
+func TestApplicationRunNoSignals(t *testing.T) {
+	app := NewApplication()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// RunNoSignals should use the externally managed context, not app.ctx
+	err := app.RunNoSignals(ctx)
+	if err == nil {
+		t.Fatal("Expected error when the externally managed context is cancelled")
+	}
+
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled error, got: %v", err)
+	}
+}
+
+func TestApplicationMetricsFile(t *testing.T) {
+	metricsPath := filepath.Join(t.TempDir(), "metrics.prom")
+
+	// Suppress stdout from the formatter for this test
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		os.Stdout = oldStdout
+		w.Close()
+	}()
+
+	app := NewApplication()
+	app.metricsPath = metricsPath
+
+	oldStdin := os.Stdin
+	r, wIn, _ := os.Pipe()
+	os.Stdin = r
+	go func() {
+		defer wIn.Close()
+		wIn.Write([]byte("12:34:56.789 12345678.5\n12:34:57.123 12345679.1\n"))
+	}()
+	defer func() {
+		os.Stdin = oldStdin
+	}()
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		t.Fatalf("Expected metrics file to be written: %v", err)
+	}
+
+	output := string(data)
+	expectedContains := []string{"records_processed 2", "errors_total 0", "total_fare"}
+	for _, expected := range expectedContains {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected metrics file to contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestApplicationSilentMode(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	oldStderr := os.Stderr
+	r2, w2, _ := os.Pipe()
+	os.Stderr = w2
+
+	defer func() {
+		os.Stdout = oldStdout
+		os.Stderr = oldStderr
+	}()
+
+	app := NewApplication()
+	app.logger.SetLevel(loggingsystem.LevelError)
+	app.formatter = outputformatter.NewNoopFormatter()
+
+	oldStdin := os.Stdin
+	r3, w3, _ := os.Pipe()
+	os.Stdin = r3
+	go func() {
+		defer w3.Close()
+		w3.Write([]byte("12:34:56.789 12345678.5\n12:34:57.123 12345679.1\n"))
+	}()
+	defer func() {
+		os.Stdin = oldStdin
+	}()
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+
+	w.Close()
+	var stdout bytes.Buffer
+	stdout.ReadFrom(r)
+
+	w2.Close()
+	var stderr bytes.Buffer
+	stderr.ReadFrom(r2)
+
+	if stdout.Len() != 0 {
+		t.Errorf("Expected empty stdout in silent mode, got: %s", stdout.String())
+	}
+
+	if stderr.Len() != 0 {
+		t.Errorf("Expected empty stderr in silent mode (INFO logs suppressed), got: %s", stderr.String())
+	}
+}
+
+func TestApplicationFollowMode_RunningFareUpdates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.txt")
+	if err := os.WriteFile(path, []byte("12:34:56.789 12345678.5\n"), 0o644); err != nil {
+		t.Fatalf("failed to write initial feed file: %v", err)
+	}
+
+	var out syncBuffer
+	app := NewApplicationWith(Dependencies{Formatter: outputformatter.NewFormatterWithOutput(&out)})
+	app.StreamingMode = true
+	app.FollowMode = true
+	app.InputPath = path
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runDone := make(chan error, 1)
+	go func() {
+		runDone <- app.RunNoSignals(ctx)
+	}()
+
+	waitForUpdateCount := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(3 * time.Second)
+		for time.Now().Before(deadline) {
+			if strings.Count(out.String(), "\n") >= want {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for %d running fare updates, got output: %q", want, out.String())
+	}
+
+	waitForUpdateCount(1)
+	firstUpdate := out.String()
+
+	// Append more lines after the initial read reached EOF; follow mode
+	// should pick them up without restarting the stream.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to reopen feed file for append: %v", err)
+	}
+	if _, err := f.WriteString("12:34:57.123 12345679.1\n12:34:58.456 12345680.3\n"); err != nil {
+		t.Fatalf("failed to append to feed file: %v", err)
+	}
+	f.Close()
+
+	waitForUpdateCount(3)
+	finalUpdate := out.String()
+
+	if finalUpdate == firstUpdate {
+		t.Errorf("Expected running fare output to grow after appending lines, stayed at: %q", firstUpdate)
+	}
+
+	cancel()
+	select {
+	case err := <-runDone:
+		if err != nil {
+			t.Errorf("Expected RunNoSignals to stop cleanly after cancellation, got error: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for follow mode to stop after cancellation")
+	}
+}
+
+// TestApplicationFollowMode_Timeout verifies that -timeout still aborts with
+// errorhandler.ErrProcessingTimeout in follow mode, instead of being
+// shadowed by FollowMode's own ctx.Done() handling (which otherwise treats
+// any cancellation as a user-initiated stop and returns nil).
+func TestApplicationFollowMode_Timeout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.txt")
+	if err := os.WriteFile(path, []byte("12:34:56.789 12345678.5\n"), 0o644); err != nil {
+		t.Fatalf("failed to write initial feed file: %v", err)
+	}
+
+	app := NewApplicationWith(Dependencies{Formatter: outputformatter.NewFormatterWithOutput(&bytes.Buffer{})})
+	app.StreamingMode = true
+	app.FollowMode = true
+	app.InputPath = path
+	app.Timeout = 50 * time.Millisecond
+
+	err := app.Run()
+	if !errors.Is(err, errorhandler.ErrProcessingTimeout) {
+		t.Fatalf("Expected errorhandler.ErrProcessingTimeout, got: %v", err)
+	}
+}
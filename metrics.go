@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Metrics captures the processing counters emitted in Prometheus text
+// exposition format at the end of a run
+type Metrics struct {
+	RecordsProcessed int
+	RecordsSkipped   int
+	ErrorsTotal      int
+	DurationSeconds  float64
+	TotalFare        float64
+}
+
+// WriteTo writes m to w in Prometheus text exposition format
+func (m Metrics) WriteTo(w io.Writer) (int64, error) {
+	lines := []struct {
+		name  string
+		help  string
+		typ   string
+		value string
+	}{
+		{"records_processed", "Number of records successfully processed", "counter", fmt.Sprintf("%d", m.RecordsProcessed)},
+		{"records_skipped", "Number of records skipped due to validation failures", "counter", fmt.Sprintf("%d", m.RecordsSkipped)},
+		{"errors_total", "Total number of parsing and validation errors encountered", "counter", fmt.Sprintf("%d", m.ErrorsTotal)},
+		{"duration_seconds", "Total processing duration in seconds", "gauge", fmt.Sprintf("%f", m.DurationSeconds)},
+		{"total_fare", "Total calculated fare for the run", "gauge", fmt.Sprintf("%f", m.TotalFare)},
+	}
+
+	var written int64
+	for _, line := range lines {
+		n, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %s\n",
+			line.name, line.help, line.name, line.typ, line.name, line.value)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// writeMetricsFile writes m to the file at path in Prometheus text exposition format
+func writeMetricsFile(path string, m Metrics) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := m.WriteTo(f); err != nil {
+		return fmt.Errorf("failed to write metrics to %q: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetrics_WriteTo(t *testing.T) {
+	var buf strings.Builder
+	metrics := Metrics{
+		RecordsProcessed: 3,
+		RecordsSkipped:   1,
+		ErrorsTotal:      2,
+		DurationSeconds:  0.125,
+		TotalFare:        440,
+	}
+
+	if _, err := metrics.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() unexpected error = %v", err)
+	}
+
+	output := buf.String()
+	expectedContains := []string{
+		"records_processed 3",
+		"records_skipped 1",
+		"errors_total 2",
+		"duration_seconds 0.125000",
+		"total_fare 440.000000",
+	}
+
+	for _, expected := range expectedContains {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected metrics output to contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestWriteMetricsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	metrics := Metrics{
+		RecordsProcessed: 5,
+		RecordsSkipped:   0,
+		ErrorsTotal:      0,
+		DurationSeconds:  1.5,
+		TotalFare:        520,
+	}
+
+	if err := writeMetricsFile(path, metrics); err != nil {
+		t.Fatalf("writeMetricsFile() unexpected error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read metrics file: %v", err)
+	}
+
+	output := string(data)
+	if !strings.Contains(output, "records_processed 5") {
+		t.Errorf("Expected metrics file to contain records_processed 5, got: %s", output)
+	}
+	if !strings.Contains(output, "total_fare 520.000000") {
+		t.Errorf("Expected metrics file to contain total_fare 520.000000, got: %s", output)
+	}
+}
@@ -25,6 +25,20 @@ type FareCalculation struct {
 	DistanceFare decimal.Decimal `json:"distance_fare"`
 	TimeFare     decimal.Decimal `json:"time_fare"`
 	TotalFare    decimal.Decimal `json:"total_fare"`
+
+	// RoundingDelta is round(TotalFare) - TotalFare, the adjustment applied
+	// when TotalFare is displayed as a whole-currency-unit integer.
+	// Downstream reconciliation can use this to recover the precise total
+	// from a rounded display value without redoing the rounding itself.
+	RoundingDelta decimal.Decimal `json:"rounding_delta"`
+
+	// TariffName and TariffVersion identify the fare table this calculation
+	// was produced by (e.g. "jp-standard" and "v1"), so output that shows
+	// multiple tariffs side by side can state which one produced a given
+	// fare. Empty when the calculator that produced this FareCalculation
+	// doesn't set an identity.
+	TariffName    string `json:"tariff_name,omitempty"`
+	TariffVersion string `json:"tariff_version,omitempty"`
 }
 
 // String implements the Stringer interface for debugging
@@ -54,4 +68,25 @@ func (pr ProcessingResult) String() string {
 // IsValid checks if the ProcessingResult contains valid data
 func (pr ProcessingResult) IsValid() bool {
 	return pr.Error == nil && len(pr.Records) > 0 && !pr.Calculation.TotalFare.IsNegative()
+}
+
+// ProcessingDiagnostics summarizes why an input stream produced no (or too
+// few) usable records, so an "insufficient data" failure comes with
+// actionable detail instead of a bare error message. ParseErrorsByType and
+// ValidationErrorsByType are keyed by the String() form of the producing
+// package's own error-type enum (inputparser.ErrorType,
+// datavalidator.ValidationErrorType), since this package doesn't depend on
+// either, to avoid forcing callers outside those packages to import them
+// just to read a diagnostic report.
+type ProcessingDiagnostics struct {
+	TotalLines             int            `json:"total_lines"`
+	BlankLines             int            `json:"blank_lines"`
+	ParseErrorsByType      map[string]int `json:"parse_errors_by_type,omitempty"`
+	ValidationErrorsByType map[string]int `json:"validation_errors_by_type,omitempty"`
+}
+
+// String implements the Stringer interface for debugging
+func (pd ProcessingDiagnostics) String() string {
+	return fmt.Sprintf("ProcessingDiagnostics{TotalLines: %d, BlankLines: %d, ParseErrors: %d types, ValidationErrors: %d types}",
+		pd.TotalLines, pd.BlankLines, len(pd.ParseErrorsByType), len(pd.ValidationErrorsByType))
 }
\ No newline at end of file
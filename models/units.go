@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Meters wraps decimal.Decimal to make a distance value's unit explicit at
+// the type level, so a fare (yen) can no longer be passed where a distance
+// (meters) is expected without an explicit conversion. It carries no
+// behavior of its own beyond ToKilometers/ToMeters/Equal/String; arithmetic still
+// goes through the underlying decimal.Decimal via ToMeters().
+type Meters decimal.Decimal
+
+// NewMeters wraps a raw decimal.Decimal value as a Meters distance.
+func NewMeters(d decimal.Decimal) Meters {
+	return Meters(d)
+}
+
+// ToMeters returns the underlying decimal.Decimal, for callers that need to
+// perform arithmetic decimal.Decimal itself doesn't expose on Meters.
+func (m Meters) ToMeters() decimal.Decimal {
+	return decimal.Decimal(m)
+}
+
+// ToKilometers converts the distance to kilometers.
+func (m Meters) ToKilometers() decimal.Decimal {
+	return decimal.Decimal(m).Div(decimal.NewFromInt(1000))
+}
+
+// Equal reports whether m and other represent the same distance.
+func (m Meters) Equal(other Meters) bool {
+	return decimal.Decimal(m).Equal(decimal.Decimal(other))
+}
+
+// String implements the Stringer interface for debugging
+func (m Meters) String() string {
+	return decimal.Decimal(m).String() + "m"
+}
+
+// RecordTimestamp wraps time.Time to make a record's timestamp explicit at
+// the type level, distinguishing it from other time.Time values (e.g. a
+// Clock's current-time reading) that aren't meant to be compared as record
+// positions.
+type RecordTimestamp time.Time
+
+// NewRecordTimestamp wraps a raw time.Time value as a RecordTimestamp.
+func NewRecordTimestamp(t time.Time) RecordTimestamp {
+	return RecordTimestamp(t)
+}
+
+// ToTime returns the underlying time.Time, for callers that need the full
+// time.Time API RecordTimestamp doesn't expose directly.
+func (rt RecordTimestamp) ToTime() time.Time {
+	return time.Time(rt)
+}
+
+// Equal reports whether rt and other represent the same instant.
+func (rt RecordTimestamp) Equal(other RecordTimestamp) bool {
+	return time.Time(rt).Equal(time.Time(other))
+}
+
+// String implements the Stringer interface for debugging
+func (rt RecordTimestamp) String() string {
+	return time.Time(rt).Format("15:04:05.000")
+}
+
+// DistanceMeters returns dr.Distance wrapped as a Meters value, for callers
+// that want the unit made explicit rather than working with the raw
+// decimal.Decimal field directly. The Distance field itself is unchanged,
+// preserving JSON encoding and existing direct field access.
+func (dr DistanceRecord) DistanceMeters() Meters {
+	return NewMeters(dr.Distance)
+}
+
+// RecordTimestamp returns dr.Timestamp wrapped as a RecordTimestamp value,
+// for callers that want the role made explicit rather than working with the
+// raw time.Time field directly. The Timestamp field itself is unchanged,
+// preserving JSON encoding and existing direct field access.
+func (dr DistanceRecord) RecordTimestamp() RecordTimestamp {
+	return NewRecordTimestamp(dr.Timestamp)
+}
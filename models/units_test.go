@@ -0,0 +1,91 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMeters(t *testing.T) {
+	t.Run("ToKilometers converts correctly", func(t *testing.T) {
+		m := NewMeters(decimal.NewFromInt(12000))
+		expected := decimal.NewFromInt(12)
+		if !m.ToKilometers().Equal(expected) {
+			t.Errorf("ToKilometers() = %s, want %s", m.ToKilometers(), expected)
+		}
+	})
+
+	t.Run("ToMeters returns the underlying decimal", func(t *testing.T) {
+		d := decimal.NewFromInt(500)
+		m := NewMeters(d)
+		if !m.ToMeters().Equal(d) {
+			t.Errorf("ToMeters() = %s, want %s", m.ToMeters(), d)
+		}
+	})
+
+	t.Run("Equal compares distance values", func(t *testing.T) {
+		a := NewMeters(decimal.NewFromInt(1000))
+		b := NewMeters(decimal.NewFromInt(1000))
+		c := NewMeters(decimal.NewFromInt(2000))
+		if !a.Equal(b) {
+			t.Error("Equal() = false for equal distances, want true")
+		}
+		if a.Equal(c) {
+			t.Error("Equal() = true for different distances, want false")
+		}
+	})
+
+	t.Run("String method", func(t *testing.T) {
+		m := NewMeters(decimal.NewFromInt(1500))
+		if got, want := m.String(), "1500m"; got != want {
+			t.Errorf("String() = %s, want %s", got, want)
+		}
+	})
+}
+
+func TestRecordTimestamp(t *testing.T) {
+	t.Run("ToTime returns the underlying time.Time", func(t *testing.T) {
+		now, _ := time.Parse("15:04:05.000", "14:30:25.123")
+		rt := NewRecordTimestamp(now)
+		if !rt.ToTime().Equal(now) {
+			t.Errorf("ToTime() = %s, want %s", rt.ToTime(), now)
+		}
+	})
+
+	t.Run("Equal compares timestamps", func(t *testing.T) {
+		a, _ := time.Parse("15:04:05.000", "14:30:25.123")
+		b, _ := time.Parse("15:04:05.000", "14:30:25.123")
+		c, _ := time.Parse("15:04:05.000", "14:30:26.123")
+		if !NewRecordTimestamp(a).Equal(NewRecordTimestamp(b)) {
+			t.Error("Equal() = false for equal timestamps, want true")
+		}
+		if NewRecordTimestamp(a).Equal(NewRecordTimestamp(c)) {
+			t.Error("Equal() = true for different timestamps, want false")
+		}
+	})
+
+	t.Run("String method", func(t *testing.T) {
+		ts, _ := time.Parse("15:04:05.000", "14:30:25.123")
+		rt := NewRecordTimestamp(ts)
+		if got, want := rt.String(), "14:30:25.123"; got != want {
+			t.Errorf("String() = %s, want %s", got, want)
+		}
+	})
+}
+
+func TestDistanceRecord_UnitAccessors(t *testing.T) {
+	ts, _ := time.Parse("15:04:05.000", "14:30:25.123")
+	dr := DistanceRecord{
+		Timestamp: ts,
+		Distance:  decimal.NewFromInt(12000),
+	}
+
+	if !dr.DistanceMeters().Equal(NewMeters(dr.Distance)) {
+		t.Errorf("DistanceMeters() = %s, want %s", dr.DistanceMeters(), NewMeters(dr.Distance))
+	}
+
+	if !dr.RecordTimestamp().Equal(NewRecordTimestamp(dr.Timestamp)) {
+		t.Errorf("RecordTimestamp() = %s, want %s", dr.RecordTimestamp(), NewRecordTimestamp(dr.Timestamp))
+	}
+}
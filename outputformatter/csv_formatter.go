@@ -0,0 +1,164 @@
+package outputformatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+
+	"golang-taxi-fare/models"
+)
+
+// utf8BOM is the three-byte UTF-8 byte order mark. Prepending it is a
+// Windows Excel convention: without it, Excel guesses the file's encoding
+// from locale settings and can garble non-ASCII currency symbols in CSV
+// output it didn't produce itself.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// CSVFormatter implements the OutputFormatter interface, emitting
+// comma-separated output suitable for opening directly in a spreadsheet.
+type CSVFormatter struct {
+	output io.Writer
+
+	// WriteBOM, when true, prepends the UTF-8 byte order mark before the
+	// first row this formatter writes, so the file opens with the correct
+	// encoding in Excel on Windows. Defaults to false, preserving plain
+	// UTF-8 output with no BOM. Written at most once per formatter instance,
+	// ahead of whichever Format method is called first.
+	WriteBOM bool
+
+	wroteBOM bool
+}
+
+// NewCSVFormatter creates a formatter with CSV output to stdout
+func NewCSVFormatter() OutputFormatter {
+	return NewCSVFormatterWithOutput(os.Stdout)
+}
+
+// NewCSVFormatterWithOutput creates a CSV formatter with custom output writer
+func NewCSVFormatterWithOutput(output io.Writer) OutputFormatter {
+	return &CSVFormatter{output: output}
+}
+
+// writeBOM writes the UTF-8 BOM ahead of cf's first row, when WriteBOM is
+// enabled.
+func (cf *CSVFormatter) writeBOM() error {
+	if !cf.WriteBOM || cf.wroteBOM {
+		return nil
+	}
+	cf.wroteBOM = true
+	if _, err := cf.output.Write(utf8BOM); err != nil {
+		return fmt.Errorf("error writing UTF-8 BOM: %w", err)
+	}
+	return nil
+}
+
+// FormatCurrentFare formats the fare calculation as a two-row CSV table: a
+// header row followed by one data row.
+func (cf *CSVFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
+	if err := cf.writeBOM(); err != nil {
+		return err
+	}
+	w := csv.NewWriter(cf.output)
+	rows := [][]string{
+		{"base_fare", "distance_fare", "time_fare", "total_fare", "rounding_delta"},
+		{
+			calculation.BaseFare.String(),
+			calculation.DistanceFare.String(),
+			calculation.TimeFare.String(),
+			calculation.TotalFare.String(),
+			calculation.RoundingDelta.String(),
+		},
+	}
+	return writeCSVRows(w, rows)
+}
+
+// FormatRecords formats the records as a CSV table with a header row
+// followed by one row per record.
+func (cf *CSVFormatter) FormatRecords(records []models.DistanceRecord) error {
+	if err := cf.writeBOM(); err != nil {
+		return err
+	}
+	w := csv.NewWriter(cf.output)
+	rows := [][]string{{"timestamp", "distance"}}
+	for _, record := range records {
+		rows = append(rows, []string{
+			record.Timestamp.Format("15:04:05.000"),
+			record.Distance.String(),
+		})
+	}
+	return writeCSVRows(w, rows)
+}
+
+// FormatProcessingResult formats the processing result as the records table
+// followed by a blank separator row and the fare breakdown table.
+func (cf *CSVFormatter) FormatProcessingResult(result models.ProcessingResult) error {
+	if err := cf.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	w := csv.NewWriter(cf.output)
+	if err := writeCSVRows(w, [][]string{{}}); err != nil {
+		return err
+	}
+	return cf.FormatCurrentFare(result.Calculation)
+}
+
+// FormatSummaryStatistics formats the computed statistics and fare breakdown
+// as two CSV tables separated by a blank row.
+func (cf *CSVFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
+	if err := cf.writeBOM(); err != nil {
+		return err
+	}
+	stats := calculateStatistics(records, calculation)
+	w := csv.NewWriter(cf.output)
+	rows := [][]string{
+		{"total_records", "total_distance_km", "average_distance_km", "min_distance_km", "max_distance_km"},
+		{
+			fmt.Sprintf("%d", stats.TotalRecords),
+			stats.TotalDistance.StringFixed(3),
+			stats.AverageDistance.StringFixed(3),
+			stats.MinDistance.StringFixed(3),
+			stats.MaxDistance.StringFixed(3),
+		},
+		{},
+	}
+	if err := writeCSVRows(w, rows); err != nil {
+		return err
+	}
+	return cf.FormatCurrentFare(calculation)
+}
+
+// FormatFullReport writes the processing result table, a blank separator
+// row, the full records table, another blank row, and summary statistics, so
+// a caller wanting everything doesn't need to call all three separately.
+func (cf *CSVFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if err := cf.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	w := csv.NewWriter(cf.output)
+	if err := writeCSVRows(w, [][]string{{}}); err != nil {
+		return err
+	}
+	if err := cf.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	if err := writeCSVRows(w, [][]string{{}}); err != nil {
+		return err
+	}
+	return cf.FormatSummaryStatistics(result.Records, result.Calculation)
+}
+
+// writeCSVRows writes rows through w and flushes, surfacing any write or
+// flush error as a wrapped error.
+func writeCSVRows(w *csv.Writer, rows [][]string) error {
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("error writing CSV output: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("error writing CSV output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package outputformatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestNewCSVFormatter(t *testing.T) {
+	formatter := NewCSVFormatter()
+	if formatter == nil {
+		t.Error("Expected non-nil formatter")
+	}
+
+	if _, ok := formatter.(OutputFormatter); !ok {
+		t.Error("CSVFormatter should implement OutputFormatter interface")
+	}
+}
+
+func TestCSVFormatter_FormatRecords(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewCSVFormatterWithOutput(&buf).(*CSVFormatter)
+
+	timestamp, _ := time.Parse("15:04:05.000", "14:30:25.123")
+	records := []models.DistanceRecord{
+		{Timestamp: timestamp, Distance: decimal.NewFromInt(1000)},
+	}
+
+	if err := formatter.FormatRecords(records); err != nil {
+		t.Fatalf("FormatRecords() unexpected error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.HasPrefix(output, "timestamp,distance\n") {
+		t.Errorf("FormatRecords() output missing header, got:\n%s", output)
+	}
+	if !strings.Contains(output, "14:30:25.123,1000") {
+		t.Errorf("FormatRecords() output missing data row, got:\n%s", output)
+	}
+}
+
+func TestCSVFormatter_WriteBOM(t *testing.T) {
+	t.Run("disabled by default: no BOM bytes", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewCSVFormatterWithOutput(&buf).(*CSVFormatter)
+
+		if err := formatter.FormatRecords(nil); err != nil {
+			t.Fatalf("FormatRecords() unexpected error = %v", err)
+		}
+
+		if bytes.HasPrefix(buf.Bytes(), utf8BOM) {
+			t.Error("expected no BOM bytes with WriteBOM disabled")
+		}
+	})
+
+	t.Run("enabled: BOM precedes the header", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewCSVFormatterWithOutput(&buf).(*CSVFormatter)
+		formatter.WriteBOM = true
+
+		if err := formatter.FormatRecords(nil); err != nil {
+			t.Fatalf("FormatRecords() unexpected error = %v", err)
+		}
+
+		if !bytes.HasPrefix(buf.Bytes(), utf8BOM) {
+			t.Errorf("expected output to start with the UTF-8 BOM, got: %v", buf.Bytes())
+		}
+		if !strings.Contains(buf.String(), "timestamp,distance") {
+			t.Errorf("expected header after the BOM, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("enabled: BOM written only once across multiple calls", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewCSVFormatterWithOutput(&buf).(*CSVFormatter)
+		formatter.WriteBOM = true
+
+		if err := formatter.FormatRecords(nil); err != nil {
+			t.Fatalf("FormatRecords() unexpected error = %v", err)
+		}
+		if err := formatter.FormatRecords(nil); err != nil {
+			t.Fatalf("FormatRecords() unexpected error = %v", err)
+		}
+
+		if count := bytes.Count(buf.Bytes(), utf8BOM); count != 1 {
+			t.Errorf("expected exactly one BOM across two calls, found %d", count)
+		}
+	})
+}
+
+func TestCSVFormatter_FormatCurrentFare(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewCSVFormatterWithOutput(&buf).(*CSVFormatter)
+
+	calculation := models.FareCalculation{
+		BaseFare:     decimal.NewFromInt(410),
+		DistanceFare: decimal.NewFromInt(200),
+		TimeFare:     decimal.NewFromInt(0),
+		TotalFare:    decimal.NewFromInt(610),
+	}
+
+	if err := formatter.FormatCurrentFare(calculation); err != nil {
+		t.Fatalf("FormatCurrentFare() unexpected error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "base_fare,distance_fare,time_fare,total_fare,rounding_delta") {
+		t.Errorf("FormatCurrentFare() output missing header, got:\n%s", output)
+	}
+	if !strings.Contains(output, "410,200,0,610,0") {
+		t.Errorf("FormatCurrentFare() output missing data row, got:\n%s", output)
+	}
+}
@@ -0,0 +1,50 @@
+package outputformatter
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/dailyreport"
+)
+
+// DailyReportFormatter renders a dailyreport.DailyReport, a fleet-dashboard
+// style batch summary distinct from the per-run output OutputFormatter
+// covers, which is why it's its own interface rather than another
+// OutputFormatter method.
+type DailyReportFormatter interface {
+	// FormatDailyReport formats and displays an aggregate daily report
+	FormatDailyReport(report dailyreport.DailyReport) error
+}
+
+// ConsoleDailyReportFormatter implements DailyReportFormatter with console output
+type ConsoleDailyReportFormatter struct {
+	output io.Writer
+}
+
+// NewDailyReportFormatter creates a DailyReportFormatter with stdout output
+func NewDailyReportFormatter() DailyReportFormatter {
+	return NewDailyReportFormatterWithOutput(os.Stdout)
+}
+
+// NewDailyReportFormatterWithOutput creates a DailyReportFormatter with a custom output writer
+func NewDailyReportFormatterWithOutput(output io.Writer) DailyReportFormatter {
+	return &ConsoleDailyReportFormatter{output: output}
+}
+
+// FormatDailyReport formats and displays an aggregate daily report
+func (df *ConsoleDailyReportFormatter) FormatDailyReport(report dailyreport.DailyReport) error {
+	fmt.Fprintln(df.output, "Daily Report")
+	fmt.Fprintln(df.output, "------------")
+	fmt.Fprintf(df.output, "Total Trips:\t%d\n", report.TotalTrips)
+	fmt.Fprintf(df.output, "Total Fare:\t%s yen\n", report.TotalFare.StringFixed(0))
+	fmt.Fprintf(df.output, "Total Distance:\t%s km\n", report.TotalDistance.Div(decimal.NewFromInt(1000)).StringFixed(3))
+	fmt.Fprintf(df.output, "Average Fare:\t%s yen\n", report.AverageFare.StringFixed(2))
+	if report.BusiestHour < 0 {
+		fmt.Fprintln(df.output, "Busiest Hour:\tn/a")
+	} else {
+		fmt.Fprintf(df.output, "Busiest Hour:\t%02d:00-%02d:00\n", report.BusiestHour, (report.BusiestHour+1)%24)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package outputformatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/dailyreport"
+)
+
+func TestNewDailyReportFormatter(t *testing.T) {
+	formatter := NewDailyReportFormatter()
+	if formatter == nil {
+		t.Error("Expected non-nil formatter")
+	}
+}
+
+func TestConsoleDailyReportFormatter_FormatDailyReport(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewDailyReportFormatterWithOutput(&buf)
+
+	report := dailyreport.DailyReport{
+		TotalTrips:    3,
+		TotalFare:     decimal.NewFromInt(2100),
+		TotalDistance: decimal.NewFromInt(6000),
+		AverageFare:   decimal.NewFromInt(700),
+		BusiestHour:   8,
+	}
+
+	if err := formatter.FormatDailyReport(report); err != nil {
+		t.Fatalf("FormatDailyReport() unexpected error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"Total Trips:\t3", "Total Fare:\t2100 yen", "Average Fare:\t700.00 yen", "Busiest Hour:\t08:00-09:00"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatDailyReport() output missing %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestConsoleDailyReportFormatter_NoBusiestHour(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewDailyReportFormatterWithOutput(&buf)
+
+	report := dailyreport.DailyReport{BusiestHour: -1}
+
+	if err := formatter.FormatDailyReport(report); err != nil {
+		t.Fatalf("FormatDailyReport() unexpected error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Busiest Hour:\tn/a") {
+		t.Errorf("FormatDailyReport() expected n/a busiest hour, got:\n%s", buf.String())
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/shopspring/decimal"
@@ -17,21 +19,126 @@ import (
 type OutputFormatter interface {
 	// FormatCurrentFare formats and displays the current fare calculation result
 	FormatCurrentFare(calculation models.FareCalculation) error
-	
+
 	// FormatRecords formats and displays the processed records with sorting
 	FormatRecords(records []models.DistanceRecord) error
-	
+
 	// FormatProcessingResult formats and displays the complete processing result
 	FormatProcessingResult(result models.ProcessingResult) error
-	
+
 	// FormatSummaryStatistics formats and displays summary statistics
 	FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error
+
+	// FormatFullReport formats and displays FormatProcessingResult,
+	// FormatRecords, and FormatSummaryStatistics together in one call, so a
+	// caller wanting everything doesn't need to orchestrate the three
+	// separately. Each implementation composes them in whatever order and
+	// with whatever separators suit its own output style.
+	FormatFullReport(result models.ProcessingResult) error
+}
+
+// ErrorFormatter is an optional interface an OutputFormatter can implement to
+// emit a structured error body in its own format before the application
+// exits. Formatters that don't implement it (ConsoleFormatter, NoopFormatter)
+// leave error reporting to the error handler's existing stderr output.
+type ErrorFormatter interface {
+	// FormatError writes err to the formatter's output in its native format,
+	// so a caller consuming stdout (e.g. in a shell pipeline) can recover
+	// structured failure details even on the error path, not just on success.
+	FormatError(err error) error
+}
+
+// DiagnosticFormatter is an optional interface an OutputFormatter can
+// implement to emit a models.ProcessingDiagnostics report before the
+// application exits on an insufficient-data error, so a cryptic "no valid
+// records processed" failure comes with line/error-type counts a user can
+// act on. Formatters that don't implement it fall back to no diagnostic
+// output, the same way an OutputFormatter without ErrorFormatter falls back
+// to no structured error body.
+type DiagnosticFormatter interface {
+	// FormatDiagnostics writes diag to the formatter's output in its native
+	// format.
+	FormatDiagnostics(diag models.ProcessingDiagnostics) error
 }
 
 // ConsoleFormatter implements the OutputFormatter interface with console output
 type ConsoleFormatter struct {
 	output io.Writer
 	writer *tabwriter.Writer
+
+	// SectionSeparator is printed between the fare, summary, and statistics
+	// blocks. The default ("\n") reproduces the original single blank line;
+	// set to "" to disable the gap entirely, or to a custom string (e.g. a
+	// rule) when embedding this output inside other formatted text.
+	SectionSeparator string
+
+	// MinorUnitScale controls how many decimal places FormatCurrentFare
+	// displays: 0 for currencies with no minor unit (yen, the default), 2 for
+	// cents-based currencies (USD). The calculator always keeps full decimal
+	// precision internally; this only affects display rounding.
+	MinorUnitScale int
+
+	// CollapseEqualDiffs, when true, makes FormatRecords collapse runs of
+	// consecutive rows (after the existing descending sort by mileage
+	// difference) that share an identical MileageDiff into a single row
+	// annotated with the run's record count ("×12") and the time span it
+	// covers. This keeps long idle stretches of repeated zero-diff rows from
+	// cluttering the table. Defaults to false, preserving the original
+	// one-row-per-record output.
+	CollapseEqualDiffs bool
+
+	// ShowTariff, when true, makes FormatCurrentFare print a trailing
+	// "Tariff: <name> <version>" line sourced from the calculation's
+	// TariffName/TariffVersion, so output is unambiguous about which fare
+	// table produced it when multiple tariffs are in play. No line is
+	// printed when TariffName is empty, even if ShowTariff is true.
+	// Defaults to false, preserving the original output.
+	ShowTariff bool
+
+	// PageSize, when non-zero, makes FormatRecords insert a "--- page N ---"
+	// marker every PageSize displayed rows, to help scanning thousands of
+	// rows in a terminal. The marker line carries no tab-separated fields,
+	// so it breaks the tabwriter's column block at that point; columns stay
+	// aligned within each page rather than across the whole table. Counts
+	// displayed rows (a CollapseEqualDiffs run counts as one), not the
+	// underlying record count. The zero value disables paging, preserving
+	// the original unpaginated output.
+	PageSize int
+
+	// TimeFormat is the time.Format layout used to render record timestamps
+	// in FormatRecords. Defaults to "15:04:05.000" (millisecond precision),
+	// the original hardcoded layout. Set to "15:04:05" for second precision,
+	// or a layout with a finer fractional-second component for more.
+	TimeFormat string
+
+	// ThousandsSeparator, when true, makes every fare amount this formatter
+	// prints (FormatCurrentFare and the "yen" totals in FormatProcessingResult
+	// and FormatSummaryStatistics) render with ',' grouping every three
+	// integer digits, e.g. "1,234,567" instead of "1234567". The underlying
+	// decimal.Decimal value is unaffected; this only changes display.
+	// Defaults to false, preserving the original ungrouped output.
+	ThousandsSeparator bool
+
+	// EmptyRecordsMessage is printed by FormatRecords when given an empty
+	// record slice, in place of the table it would otherwise print.
+	// Defaults to "No records to display", the original hardcoded text; set
+	// it to localize the message or otherwise customize it.
+	EmptyRecordsMessage string
+
+	// EmptyStatisticsMessage is printed by FormatSummaryStatistics when
+	// given an empty record slice, in place of the statistics it would
+	// otherwise compute. Defaults to "No data for statistics", the original
+	// hardcoded text; set it to localize the message or otherwise customize
+	// it.
+	EmptyStatisticsMessage string
+
+	// TrimTrailingZeros, when true, renders distance values with their
+	// trailing zeros (and a bare trailing decimal point) stripped, e.g.
+	// "12345" instead of "12345.000", which is less noisy for whole-meter
+	// data. Columns stay aligned regardless, since the tabwriter sizes each
+	// column from its widest cell rather than a fixed format string.
+	// Defaults to false, preserving the original fixed-precision output.
+	TrimTrailingZeros bool
 }
 
 // NewFormatter creates a new ConsoleFormatter with stdout output
@@ -43,62 +150,208 @@ func NewFormatter() OutputFormatter {
 func NewFormatterWithOutput(output io.Writer) OutputFormatter {
 	writer := tabwriter.NewWriter(output, 0, 8, 1, '\t', 0)
 	return &ConsoleFormatter{
-		output: output,
-		writer: writer,
+		output:                 output,
+		writer:                 writer,
+		SectionSeparator:       "\n",
+		MinorUnitScale:         0,
+		TimeFormat:             "15:04:05.000",
+		EmptyRecordsMessage:    "No records to display",
+		EmptyStatisticsMessage: "No data for statistics",
 	}
 }
 
 // FormatCurrentFare formats and displays the current fare calculation result
 func (cf *ConsoleFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
-	// Convert decimal to integer for display (rounded)
-	totalFareInt := calculation.TotalFare.Round(0).IntPart()
-	
-	fmt.Fprintf(cf.output, "%d\n", totalFareInt)
+	amount := formatScaledAmount(calculation.TotalFare, cf.MinorUnitScale)
+	if cf.ThousandsSeparator {
+		amount = groupThousands(amount)
+	}
+	fmt.Fprintf(cf.output, "%s\n", amount)
+	if cf.ShowTariff && calculation.TariffName != "" {
+		fmt.Fprintf(cf.output, "Tariff: %s %s\n", calculation.TariffName, calculation.TariffVersion)
+	}
 	return nil
 }
 
+// formatYen renders amount, rounded to the nearest whole yen, as a string,
+// applying cf.ThousandsSeparator grouping when enabled.
+func (cf *ConsoleFormatter) formatYen(amount decimal.Decimal) string {
+	s := strconv.FormatInt(RoundFareForDisplay(amount, RoundNearest, decimal.NewFromInt(1)), 10)
+	if cf.ThousandsSeparator {
+		s = groupThousands(s)
+	}
+	return s
+}
+
+// emptyRecordsMessage returns cf.EmptyRecordsMessage, falling back to the
+// original hardcoded text when unset (e.g. a ConsoleFormatter built as a
+// struct literal rather than via NewFormatterWithOutput).
+func (cf *ConsoleFormatter) emptyRecordsMessage() string {
+	if cf.EmptyRecordsMessage == "" {
+		return "No records to display"
+	}
+	return cf.EmptyRecordsMessage
+}
+
+// emptyStatisticsMessage returns cf.EmptyStatisticsMessage, falling back to
+// the original hardcoded text when unset.
+func (cf *ConsoleFormatter) emptyStatisticsMessage() string {
+	if cf.EmptyStatisticsMessage == "" {
+		return "No data for statistics"
+	}
+	return cf.EmptyStatisticsMessage
+}
+
 // FormatRecords formats and displays the processed records with sorting
 func (cf *ConsoleFormatter) FormatRecords(records []models.DistanceRecord) error {
 	if len(records) == 0 {
-		fmt.Fprint(cf.output, "No records to display\n")
+		fmt.Fprintf(cf.output, "%s\n", cf.emptyRecordsMessage())
 		return nil
 	}
-	
+
 	// Sort records by mileage difference (descending)
 	sortedRecords := make([]RecordWithDifference, 0, len(records))
-	
+
 	for i, record := range records {
 		diff := decimal.Zero
 		if i > 0 {
 			diff = record.Distance.Sub(records[i-1].Distance)
 		}
-		
+
 		sortedRecords = append(sortedRecords, RecordWithDifference{
-			Record:          record,
-			MileageDiff:     diff,
-			Index:           i,
+			Record:      record,
+			MileageDiff: diff,
+			Index:       i,
 		})
 	}
-	
+
 	// Sort by mileage difference in descending order
-	sort.Slice(sortedRecords, func(i, j int) bool {
-		return sortedRecords[i].MileageDiff.GreaterThan(sortedRecords[j].MileageDiff)
-	})
-	
+	sortByMileageDiffDescending(sortedRecords)
+
 	// Format output using tabwriter
 	fmt.Fprintln(cf.writer, "Index\tTimestamp\tDistance\tMileage Diff")
 	fmt.Fprintln(cf.writer, "-----\t---------\t--------\t------------")
-	
-	for _, item := range sortedRecords {
+
+	var runs [][]RecordWithDifference
+	if cf.CollapseEqualDiffs {
+		runs = groupEqualDiffs(sortedRecords)
+	} else {
+		runs = make([][]RecordWithDifference, len(sortedRecords))
+		for i, item := range sortedRecords {
+			runs[i] = []RecordWithDifference{item}
+		}
+	}
+
+	for i, run := range runs {
+		cf.writeRecordRun(run)
+		if cf.PageSize > 0 && (i+1)%cf.PageSize == 0 && i+1 < len(runs) {
+			fmt.Fprintf(cf.writer, "--- page %d ---\n", (i+1)/cf.PageSize+1)
+		}
+	}
+
+	return cf.writer.Flush()
+}
+
+// byMileageDiffDescending implements sort.Interface directly, rather than
+// going through sort.Slice, so sorting doesn't pay for reflect.Swapper's
+// per-swap reflection overhead. An earlier attempt at this optimization
+// precomputed an int64 sort key per record (scaled via Decimal.Shift) to
+// avoid decimal.Cmp during comparisons; benchmarking showed it was actually
+// slower, because MileageDiff.GreaterThan already takes Decimal.Cmp's
+// allocation-free fast path whenever both sides share the same exponent (the
+// common case here, since every diff comes from subtracting two distances
+// parsed with the same scale), while Shift allocates a new Decimal per call.
+type byMileageDiffDescending []RecordWithDifference
+
+func (s byMileageDiffDescending) Len() int      { return len(s) }
+func (s byMileageDiffDescending) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byMileageDiffDescending) Less(i, j int) bool {
+	return s[i].MileageDiff.GreaterThan(s[j].MileageDiff)
+}
+
+// sortByMileageDiffDescending sorts records by MileageDiff in descending
+// order, in place.
+func sortByMileageDiffDescending(records []RecordWithDifference) {
+	sort.Sort(byMileageDiffDescending(records))
+}
+
+// timeFormat returns cf.TimeFormat, falling back to the original
+// millisecond-precision layout when unset (e.g. a ConsoleFormatter built as a
+// struct literal rather than via NewFormatterWithOutput).
+func (cf *ConsoleFormatter) timeFormat() string {
+	if cf.TimeFormat == "" {
+		return "15:04:05.000"
+	}
+	return cf.TimeFormat
+}
+
+// formatDistance renders d to precision decimal places, trimming trailing
+// zeros when cf.TrimTrailingZeros is set.
+func (cf *ConsoleFormatter) formatDistance(d decimal.Decimal, precision int32) string {
+	return formatDistanceValue(d, precision, cf.TrimTrailingZeros)
+}
+
+// formatDistanceValue renders d to precision decimal places via StringFixed,
+// then trims trailing zeros (and a bare trailing decimal point) when trim is
+// set, e.g. "12345.000" -> "12345", "12345.250" -> "12345.25".
+func formatDistanceValue(d decimal.Decimal, precision int32, trim bool) string {
+	s := d.StringFixed(precision)
+	if !trim || !strings.Contains(s, ".") {
+		return s
+	}
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// writeRecordRun writes a single table row for run. A run of one record is
+// written exactly as an uncollapsed row; a longer run is written as one
+// annotated row spanning the run's earliest to latest timestamp.
+func (cf *ConsoleFormatter) writeRecordRun(run []RecordWithDifference) {
+	if len(run) == 1 {
+		item := run[0]
 		fmt.Fprintf(cf.writer, "%d\t%s\t%s\t%s\n",
 			item.Index,
-			item.Record.Timestamp.Format("15:04:05.000"),
-			item.Record.Distance.StringFixed(1),
-			item.MileageDiff.StringFixed(1),
+			item.Record.Timestamp.Format(cf.timeFormat()),
+			cf.formatDistance(item.Record.Distance, 1),
+			cf.formatDistance(item.MileageDiff, 1),
 		)
+		return
 	}
-	
-	return cf.writer.Flush()
+
+	earliest, latest := run[0].Record.Timestamp, run[0].Record.Timestamp
+	for _, item := range run[1:] {
+		if item.Record.Timestamp.Before(earliest) {
+			earliest = item.Record.Timestamp
+		}
+		if item.Record.Timestamp.After(latest) {
+			latest = item.Record.Timestamp
+		}
+	}
+
+	fmt.Fprintf(cf.writer, "%d-%d\t%s - %s\t%s\t%s ×%d\n",
+		run[0].Index, run[len(run)-1].Index,
+		earliest.Format(cf.timeFormat()), latest.Format(cf.timeFormat()),
+		cf.formatDistance(run[len(run)-1].Record.Distance, 1),
+		cf.formatDistance(run[0].MileageDiff, 1), len(run),
+	)
+}
+
+// groupEqualDiffs splits sortedRecords into consecutive runs sharing an
+// identical MileageDiff value.
+func groupEqualDiffs(sortedRecords []RecordWithDifference) [][]RecordWithDifference {
+	if len(sortedRecords) == 0 {
+		return nil
+	}
+
+	runs := make([][]RecordWithDifference, 0, len(sortedRecords))
+	runStart := 0
+	for i := 1; i <= len(sortedRecords); i++ {
+		if i == len(sortedRecords) || !sortedRecords[i].MileageDiff.Equal(sortedRecords[runStart].MileageDiff) {
+			runs = append(runs, sortedRecords[runStart:i])
+			runStart = i
+		}
+	}
+	return runs
 }
 
 // FormatProcessingResult formats and displays the complete processing result
@@ -107,52 +360,221 @@ func (cf *ConsoleFormatter) FormatProcessingResult(result models.ProcessingResul
 		fmt.Fprintf(cf.output, "Processing failed: %v\n", result.Error)
 		return nil
 	}
-	
+
 	if !result.IsValid() {
 		fmt.Fprint(cf.output, "Invalid processing result\n")
 		return nil
 	}
-	
+
 	// Display fare calculation
 	if err := cf.FormatCurrentFare(result.Calculation); err != nil {
 		return fmt.Errorf("error formatting fare: %w", err)
 	}
-	
+
 	// Display processing summary
-	fmt.Fprintf(cf.output, "\nProcessing Summary:\n")
+	fmt.Fprintf(cf.output, "%sProcessing Summary:\n", cf.SectionSeparator)
 	fmt.Fprintf(cf.output, "Records processed: %d\n", len(result.Records))
 	fmt.Fprintf(cf.output, "Processing time: %v\n", result.TotalTime)
-	fmt.Fprintf(cf.output, "Total fare: %d yen\n", result.Calculation.TotalFare.Round(0).IntPart())
-	
+	fmt.Fprintf(cf.output, "Total fare: %s yen\n", cf.formatYen(result.Calculation.TotalFare))
+
 	return nil
 }
 
+// FormatDiagnostics implements DiagnosticFormatter, printing a short
+// plain-text breakdown of line and error-type counts. Map keys are sorted
+// for deterministic output, since Go map iteration order is randomized.
+func (cf *ConsoleFormatter) FormatDiagnostics(diag models.ProcessingDiagnostics) error {
+	fmt.Fprintf(cf.output, "Diagnostics:\n")
+	fmt.Fprintf(cf.output, "  Total lines read: %d\n", diag.TotalLines)
+	fmt.Fprintf(cf.output, "  Blank lines: %d\n", diag.BlankLines)
+	printCounts(cf.output, "  Parse errors", diag.ParseErrorsByType)
+	printCounts(cf.output, "  Validation errors", diag.ValidationErrorsByType)
+	return nil
+}
+
+// printCounts writes label's counts sorted by key, one "key: count" line per
+// entry indented under label, or "label: none" when counts is empty.
+func printCounts(output io.Writer, label string, counts map[string]int) {
+	if len(counts) == 0 {
+		fmt.Fprintf(output, "%s: none\n", label)
+		return
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(output, "%s:\n", label)
+	for _, k := range keys {
+		fmt.Fprintf(output, "    %s: %d\n", k, counts[k])
+	}
+}
+
 // FormatSummaryStatistics formats and displays summary statistics
 func (cf *ConsoleFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
 	if len(records) == 0 {
-		fmt.Fprint(cf.output, "No data for statistics\n")
+		fmt.Fprintf(cf.output, "%s\n", cf.emptyStatisticsMessage())
 		return nil
 	}
-	
+
 	// Calculate statistics
 	stats := calculateStatistics(records, calculation)
-	
+
 	// Format statistics using tabwriter
-	fmt.Fprintln(cf.writer, "\nSummary Statistics")
+	fmt.Fprintf(cf.writer, "%sSummary Statistics\n", cf.SectionSeparator)
 	fmt.Fprintln(cf.writer, "------------------")
 	fmt.Fprintf(cf.writer, "Total Records:\t%d\n", stats.TotalRecords)
-	fmt.Fprintf(cf.writer, "Total Distance:\t%s km\n", stats.TotalDistance.StringFixed(3))
-	fmt.Fprintf(cf.writer, "Average Distance:\t%s km\n", stats.AverageDistance.StringFixed(3))
-	fmt.Fprintf(cf.writer, "Min Distance:\t%s km\n", stats.MinDistance.StringFixed(3))
-	fmt.Fprintf(cf.writer, "Max Distance:\t%s km\n", stats.MaxDistance.StringFixed(3))
-	fmt.Fprintf(cf.writer, "Base Fare:\t%d yen\n", calculation.BaseFare.Round(0).IntPart())
-	fmt.Fprintf(cf.writer, "Distance Fare:\t%d yen\n", calculation.DistanceFare.Round(0).IntPart())
-	fmt.Fprintf(cf.writer, "Time Fare:\t%d yen\n", calculation.TimeFare.Round(0).IntPart())
-	fmt.Fprintf(cf.writer, "Total Fare:\t%d yen\n", calculation.TotalFare.Round(0).IntPart())
-	
+	fmt.Fprintf(cf.writer, "Total Distance:\t%s km\n", cf.formatDistance(stats.TotalDistance, 3))
+	fmt.Fprintf(cf.writer, "Average Distance:\t%s km\n", cf.formatDistance(stats.AverageDistance, 3))
+	fmt.Fprintf(cf.writer, "Min Distance:\t%s km\n", cf.formatDistance(stats.MinDistance, 3))
+	fmt.Fprintf(cf.writer, "Max Distance:\t%s km\n", cf.formatDistance(stats.MaxDistance, 3))
+	fmt.Fprintf(cf.writer, "Base Fare:\t%s yen\n", cf.formatYen(calculation.BaseFare))
+	fmt.Fprintf(cf.writer, "Distance Fare:\t%s yen\n", cf.formatYen(calculation.DistanceFare))
+	fmt.Fprintf(cf.writer, "Time Fare:\t%s yen\n", cf.formatYen(calculation.TimeFare))
+	fmt.Fprintf(cf.writer, "Total Fare:\t%s yen\n", cf.formatYen(calculation.TotalFare))
+
 	return cf.writer.Flush()
 }
 
+// FormatFullReport prints the processing result, the full records table, and
+// summary statistics in that order, separated by cf.SectionSeparator, so a
+// caller wanting everything doesn't need to call all three separately.
+func (cf *ConsoleFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if err := cf.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	fmt.Fprint(cf.output, cf.SectionSeparator)
+	if err := cf.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	fmt.Fprint(cf.output, cf.SectionSeparator)
+	return cf.FormatSummaryStatistics(result.Records, result.Calculation)
+}
+
+// DisplayRoundingMode selects how RoundFareForDisplay rounds a fare amount
+// that doesn't land exactly on a display increment.
+type DisplayRoundingMode int
+
+const (
+	// RoundNearest rounds to the nearest increment, ties away from zero.
+	// This is the rounding every formatter applied before
+	// RoundFareForDisplay existed (plain decimal.Decimal.Round(0)).
+	RoundNearest DisplayRoundingMode = iota
+	// RoundUp always rounds toward positive infinity.
+	RoundUp
+	// RoundDown always rounds toward negative infinity (truncates).
+	RoundDown
+)
+
+// RoundFareForDisplay rounds amount to the nearest multiple of increment
+// according to mode and returns the result as an int64, ready to format
+// directly. Every formatter that renders a fare amount as a whole number
+// calls this rather than rounding independently, so display rounding is
+// guaranteed consistent across formatters and is testable in one place.
+// increment <= 0 is treated as 1 (whole-currency-unit rounding), matching
+// what every formatter did before this helper existed.
+func RoundFareForDisplay(amount decimal.Decimal, mode DisplayRoundingMode, increment decimal.Decimal) int64 {
+	if increment.IsZero() || increment.IsNegative() {
+		increment = decimal.NewFromInt(1)
+	}
+
+	units := amount.Div(increment)
+	var roundedUnits decimal.Decimal
+	switch mode {
+	case RoundUp:
+		roundedUnits = units.Ceil()
+	case RoundDown:
+		roundedUnits = units.Floor()
+	default:
+		roundedUnits = units.Round(0)
+	}
+
+	return roundedUnits.Mul(increment).IntPart()
+}
+
+// formatScaledAmount renders amount rounded to scale decimal places (0 for
+// currencies with no minor unit such as yen, 2 for cents-based currencies
+// such as USD). The underlying decimal.Decimal is never mutated, so callers
+// keep full precision for any further calculation.
+func formatScaledAmount(amount decimal.Decimal, scale int) string {
+	return amount.Round(int32(scale)).StringFixed(int32(scale))
+}
+
+// groupThousands inserts ',' as a thousands separator into the integer part
+// of a formatted decimal string (as produced by formatScaledAmount or
+// strconv.FormatInt), leaving a leading minus sign and any decimal point
+// and fractional digits untouched.
+func groupThousands(s string) string {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	digits := len(intPart)
+	for i, digit := range intPart {
+		if i > 0 && (digits-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+
+	result := b.String()
+	if hasFrac {
+		result += "." + fracPart
+	}
+	if neg {
+		result = "-" + result
+	}
+	return result
+}
+
+// fareCompositionBarWidth is the fixed character width FormatFareComposition
+// scales every bar to, regardless of the fare's magnitude.
+const fareCompositionBarWidth = 40
+
+// FormatFareComposition writes an ASCII bar chart to w showing how calc's
+// TotalFare splits across its Base, Distance, and Time components, one bar
+// per component scaled proportionally to a fixed width. FareCalculation
+// doesn't separate the distance component into standard/extended tiers
+// (that breakdown only exists on farecalculator.FareBreakdown, which this
+// function doesn't take), so the bars reflect the three components
+// FareCalculation actually exposes. A zero TotalFare renders every bar
+// empty rather than dividing by zero.
+func FormatFareComposition(calc models.FareCalculation, w io.Writer) error {
+	components := []struct {
+		label  string
+		amount decimal.Decimal
+	}{
+		{"Base", calc.BaseFare},
+		{"Distance", calc.DistanceFare},
+		{"Time", calc.TimeFare},
+	}
+
+	for _, c := range components {
+		filled := 0
+		if !calc.TotalFare.IsZero() {
+			ratio := c.amount.Div(calc.TotalFare)
+			filled = int(ratio.Mul(decimal.NewFromInt(fareCompositionBarWidth)).Round(0).IntPart())
+			if filled < 0 {
+				filled = 0
+			}
+			if filled > fareCompositionBarWidth {
+				filled = fareCompositionBarWidth
+			}
+		}
+
+		bar := strings.Repeat("#", filled) + strings.Repeat(" ", fareCompositionBarWidth-filled)
+		if _, err := fmt.Fprintf(w, "%-8s [%s] %s\n", c.label, bar, c.amount.StringFixed(0)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RecordWithDifference represents a record with its mileage difference
 type RecordWithDifference struct {
 	Record      models.DistanceRecord
@@ -174,32 +596,32 @@ func calculateStatistics(records []models.DistanceRecord, calculation models.Far
 	if len(records) == 0 {
 		return Statistics{}
 	}
-	
+
 	stats := Statistics{
 		TotalRecords:  len(records),
 		MinDistance:   records[0].Distance,
 		MaxDistance:   records[0].Distance,
 		TotalDistance: decimal.Zero,
 	}
-	
+
 	// Calculate min, max, and total
 	for _, record := range records {
 		stats.TotalDistance = stats.TotalDistance.Add(record.Distance)
-		
+
 		if record.Distance.LessThan(stats.MinDistance) {
 			stats.MinDistance = record.Distance
 		}
-		
+
 		if record.Distance.GreaterThan(stats.MaxDistance) {
 			stats.MaxDistance = record.Distance
 		}
 	}
-	
+
 	// Calculate average
 	if len(records) > 0 {
 		stats.AverageDistance = stats.TotalDistance.Div(decimal.NewFromInt(int64(len(records))))
 	}
-	
+
 	return stats
 }
 
@@ -220,7 +642,7 @@ func NewCompactFormatterWithOutput(output io.Writer) OutputFormatter {
 
 // FormatCurrentFare formats the fare as a single integer
 func (cf *CompactFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
-	totalFareInt := calculation.TotalFare.Round(0).IntPart()
+	totalFareInt := RoundFareForDisplay(calculation.TotalFare, RoundNearest, decimal.NewFromInt(1))
 	fmt.Fprintf(cf.output, "%d\n", totalFareInt)
 	return nil
 }
@@ -242,26 +664,72 @@ func (cf *CompactFormatter) FormatProcessingResult(result models.ProcessingResul
 	if result.Error != nil {
 		return result.Error
 	}
-	
+
 	return cf.FormatCurrentFare(result.Calculation)
 }
 
 // FormatSummaryStatistics formats statistics compactly
 func (cf *CompactFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
-	if len(records) == 0 {
-		return nil
-	}
-	
-	fmt.Fprintf(cf.output, "Records: %d, Fare: %d yen\n", 
-		len(records), 
-		calculation.TotalFare.Round(0).IntPart())
+	fmt.Fprintf(cf.output, "Records: %d, Fare: %d yen\n",
+		len(records),
+		RoundFareForDisplay(calculation.TotalFare, RoundNearest, decimal.NewFromInt(1)))
 	return nil
 }
 
+// FormatFullReport prints the processing result, records, and summary
+// statistics one after another, matching the compact style's plain
+// line-per-call output with no separator.
+func (cf *CompactFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if err := cf.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	if err := cf.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	return cf.FormatSummaryStatistics(result.Records, result.Calculation)
+}
+
 // DebugFormatter provides detailed output for debugging purposes
 type DebugFormatter struct {
 	output io.Writer
 	writer *tabwriter.Writer
+
+	// ShowSourceLine adds a "Line" column to FormatRecordsWithLines output,
+	// mapping each record back to its original input line number
+	ShowSourceLine bool
+
+	// ShowTariff, when true, makes FormatCurrentFare print a trailing
+	// "Tariff: <name> <version>" line sourced from the calculation's
+	// TariffName/TariffVersion. No line is printed when TariffName is
+	// empty, even if ShowTariff is true. Defaults to false, preserving the
+	// original output.
+	ShowTariff bool
+
+	// TimeFormat is the time.Format layout used to render record timestamps
+	// in FormatRecords and FormatRecordsWithLines. Defaults to
+	// "15:04:05.000" (millisecond precision), the original hardcoded
+	// layout. Set to "15:04:05" for second precision, or a layout with a
+	// finer fractional-second component for more.
+	TimeFormat string
+
+	// EmptyRecordsMessage is printed by FormatRecords and
+	// FormatRecordsWithLines when given an empty record slice, in place of
+	// the table they would otherwise print. Defaults to "No records to
+	// display", the original hardcoded text; set it to localize the
+	// message or otherwise customize it.
+	EmptyRecordsMessage string
+
+	// TrimTrailingZeros, when true, renders distance values with their
+	// trailing zeros (and a bare trailing decimal point) stripped, e.g.
+	// "12345" instead of "12345.000". See ConsoleFormatter.TrimTrailingZeros
+	// for the full rationale. Defaults to false.
+	TrimTrailingZeros bool
+}
+
+// formatDistance renders d to precision decimal places, trimming trailing
+// zeros when df.TrimTrailingZeros is set.
+func (df *DebugFormatter) formatDistance(d decimal.Decimal, precision int32) string {
+	return formatDistanceValue(d, precision, df.TrimTrailingZeros)
 }
 
 // NewDebugFormatter creates a formatter with debug output
@@ -273,9 +741,31 @@ func NewDebugFormatter() OutputFormatter {
 func NewDebugFormatterWithOutput(output io.Writer) OutputFormatter {
 	writer := tabwriter.NewWriter(output, 0, 8, 1, '\t', 0)
 	return &DebugFormatter{
-		output: output,
-		writer: writer,
+		output:              output,
+		writer:              writer,
+		TimeFormat:          "15:04:05.000",
+		EmptyRecordsMessage: "No records to display",
+	}
+}
+
+// timeFormat returns df.TimeFormat, falling back to the original
+// millisecond-precision layout when unset (e.g. a DebugFormatter built as a
+// struct literal rather than via NewDebugFormatterWithOutput).
+func (df *DebugFormatter) timeFormat() string {
+	if df.TimeFormat == "" {
+		return "15:04:05.000"
+	}
+	return df.TimeFormat
+}
+
+// emptyRecordsMessage returns df.EmptyRecordsMessage, falling back to the
+// original hardcoded text when unset (e.g. a DebugFormatter built as a
+// struct literal rather than via NewDebugFormatterWithOutput).
+func (df *DebugFormatter) emptyRecordsMessage() string {
+	if df.EmptyRecordsMessage == "" {
+		return "No records to display"
 	}
+	return df.EmptyRecordsMessage
 }
 
 // FormatCurrentFare formats the fare with detailed breakdown
@@ -283,43 +773,93 @@ func (df *DebugFormatter) FormatCurrentFare(calculation models.FareCalculation)
 	fmt.Fprintln(df.writer, "Fare Breakdown:")
 	fmt.Fprintln(df.writer, "Component\tAmount (yen)")
 	fmt.Fprintln(df.writer, "---------\t-----------")
-	fmt.Fprintf(df.writer, "Base Fare\t%d\n", calculation.BaseFare.Round(0).IntPart())
-	fmt.Fprintf(df.writer, "Distance Fare\t%d\n", calculation.DistanceFare.Round(0).IntPart())
-	fmt.Fprintf(df.writer, "Time Fare\t%d\n", calculation.TimeFare.Round(0).IntPart())
+	fmt.Fprintf(df.writer, "Base Fare\t%d\n", RoundFareForDisplay(calculation.BaseFare, RoundNearest, decimal.NewFromInt(1)))
+	fmt.Fprintf(df.writer, "Distance Fare\t%d\n", RoundFareForDisplay(calculation.DistanceFare, RoundNearest, decimal.NewFromInt(1)))
+	fmt.Fprintf(df.writer, "Time Fare\t%d\n", RoundFareForDisplay(calculation.TimeFare, RoundNearest, decimal.NewFromInt(1)))
 	fmt.Fprintln(df.writer, "---------\t-----------")
-	fmt.Fprintf(df.writer, "Total\t%d\n", calculation.TotalFare.Round(0).IntPart())
-	
-	return df.writer.Flush()
+	fmt.Fprintf(df.writer, "Total\t%d\n", RoundFareForDisplay(calculation.TotalFare, RoundNearest, decimal.NewFromInt(1)))
+	if err := df.writer.Flush(); err != nil {
+		return err
+	}
+
+	if df.ShowTariff && calculation.TariffName != "" {
+		fmt.Fprintf(df.output, "Tariff: %s %s\n", calculation.TariffName, calculation.TariffVersion)
+	}
+
+	return nil
 }
 
 // FormatRecords formats records with full details
 func (df *DebugFormatter) FormatRecords(records []models.DistanceRecord) error {
 	if len(records) == 0 {
-		fmt.Fprint(df.output, "No records to display\n")
+		fmt.Fprintf(df.output, "%s\n", df.emptyRecordsMessage())
 		return nil
 	}
-	
+
 	fmt.Fprintln(df.writer, "\nDetailed Record Information:")
 	fmt.Fprintln(df.writer, "Index\tTimestamp\tDistance\tMileage Diff\tCumulative")
 	fmt.Fprintln(df.writer, "-----\t---------\t--------\t------------\t----------")
-	
+
 	for i, record := range records {
 		diff := decimal.Zero
 		if i > 0 {
 			diff = record.Distance.Sub(records[i-1].Distance)
 		}
-		
+
 		cumulative := record.Distance.Sub(records[0].Distance)
-		
+
 		fmt.Fprintf(df.writer, "%d\t%s\t%s\t%s\t%s\n",
 			i,
-			record.Timestamp.Format("15:04:05.000"),
-			record.Distance.StringFixed(3),
-			diff.StringFixed(3),
-			cumulative.StringFixed(3),
+			record.Timestamp.Format(df.timeFormat()),
+			df.formatDistance(record.Distance, 3),
+			df.formatDistance(diff, 3),
+			df.formatDistance(cumulative, 3),
 		)
 	}
-	
+
+	return df.writer.Flush()
+}
+
+// FormatRecordsWithLines formats records with full details, optionally adding a
+// "Line" column mapping each record back to its original input line number
+// (e.g. from ParseResult.Line) when ShowSourceLine is enabled. lines must be
+// either nil or parallel to records.
+func (df *DebugFormatter) FormatRecordsWithLines(records []models.DistanceRecord, lines []int) error {
+	if !df.ShowSourceLine || lines == nil {
+		return df.FormatRecords(records)
+	}
+
+	if len(lines) != len(records) {
+		return fmt.Errorf("lines must be parallel to records: got %d lines for %d records", len(lines), len(records))
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintf(df.output, "%s\n", df.emptyRecordsMessage())
+		return nil
+	}
+
+	fmt.Fprintln(df.writer, "\nDetailed Record Information:")
+	fmt.Fprintln(df.writer, "Index\tLine\tTimestamp\tDistance\tMileage Diff\tCumulative")
+	fmt.Fprintln(df.writer, "-----\t----\t---------\t--------\t------------\t----------")
+
+	for i, record := range records {
+		diff := decimal.Zero
+		if i > 0 {
+			diff = record.Distance.Sub(records[i-1].Distance)
+		}
+
+		cumulative := record.Distance.Sub(records[0].Distance)
+
+		fmt.Fprintf(df.writer, "%d\t%d\t%s\t%s\t%s\t%s\n",
+			i,
+			lines[i],
+			record.Timestamp.Format(df.timeFormat()),
+			df.formatDistance(record.Distance, 3),
+			df.formatDistance(diff, 3),
+			df.formatDistance(cumulative, 3),
+		)
+	}
+
 	return df.writer.Flush()
 }
 
@@ -327,21 +867,21 @@ func (df *DebugFormatter) FormatRecords(records []models.DistanceRecord) error {
 func (df *DebugFormatter) FormatProcessingResult(result models.ProcessingResult) error {
 	fmt.Fprintf(df.output, "Processing Result Debug Information:\n")
 	fmt.Fprintf(df.output, "=====================================\n")
-	
+
 	if result.Error != nil {
 		fmt.Fprintf(df.output, "Error: %v\n", result.Error)
 		return nil
 	}
-	
+
 	fmt.Fprintf(df.output, "Records processed: %d\n", len(result.Records))
 	fmt.Fprintf(df.output, "Processing time: %v\n", result.TotalTime)
 	fmt.Fprintf(df.output, "Valid result: %t\n", result.IsValid())
-	
+
 	// Display fare breakdown
 	if err := df.FormatCurrentFare(result.Calculation); err != nil {
 		return fmt.Errorf("error formatting fare breakdown: %w", err)
 	}
-	
+
 	// Display records
 	return df.FormatRecords(result.Records)
 }
@@ -352,26 +892,36 @@ func (df *DebugFormatter) FormatSummaryStatistics(records []models.DistanceRecor
 		fmt.Fprint(df.output, "No data for debug statistics\n")
 		return nil
 	}
-	
+
 	stats := calculateStatistics(records, calculation)
-	
+
 	fmt.Fprintln(df.writer, "\nDebug Statistics:")
 	fmt.Fprintln(df.writer, "=================")
 	fmt.Fprintf(df.writer, "Record Count:\t%d\n", stats.TotalRecords)
-	fmt.Fprintf(df.writer, "Distance Range:\t%s - %s km\n", 
-		stats.MinDistance.StringFixed(3), stats.MaxDistance.StringFixed(3))
-	fmt.Fprintf(df.writer, "Total Distance:\t%s km\n", stats.TotalDistance.StringFixed(3))
-	fmt.Fprintf(df.writer, "Average Distance:\t%s km\n", stats.AverageDistance.StringFixed(3))
-	fmt.Fprintf(df.writer, "Distance Span:\t%s km\n", 
-		stats.MaxDistance.Sub(stats.MinDistance).StringFixed(3))
-	
+	fmt.Fprintf(df.writer, "Distance Range:\t%s - %s km\n",
+		df.formatDistance(stats.MinDistance, 3), df.formatDistance(stats.MaxDistance, 3))
+	fmt.Fprintf(df.writer, "Total Distance:\t%s km\n", df.formatDistance(stats.TotalDistance, 3))
+	fmt.Fprintf(df.writer, "Average Distance:\t%s km\n", df.formatDistance(stats.AverageDistance, 3))
+	fmt.Fprintf(df.writer, "Distance Span:\t%s km\n",
+		df.formatDistance(stats.MaxDistance.Sub(stats.MinDistance), 3))
+
 	// Fare calculation details
 	fmt.Fprintln(df.writer, "\nFare Calculation Details:")
 	fmt.Fprintf(df.writer, "Base Component:\t%s yen\n", calculation.BaseFare.StringFixed(2))
 	fmt.Fprintf(df.writer, "Distance Component:\t%s yen\n", calculation.DistanceFare.StringFixed(2))
 	fmt.Fprintf(df.writer, "Time Component:\t%s yen\n", calculation.TimeFare.StringFixed(2))
 	fmt.Fprintf(df.writer, "Total (precise):\t%s yen\n", calculation.TotalFare.StringFixed(2))
-	fmt.Fprintf(df.writer, "Total (display):\t%d yen\n", calculation.TotalFare.Round(0).IntPart())
-	
+	fmt.Fprintf(df.writer, "Total (display):\t%d yen\n", RoundFareForDisplay(calculation.TotalFare, RoundNearest, decimal.NewFromInt(1)))
+
 	return df.writer.Flush()
-}
\ No newline at end of file
+}
+
+// FormatFullReport prints the processing result (which already includes the
+// fare breakdown and records) followed by summary statistics, so a caller
+// wanting everything doesn't need to call all three separately.
+func (df *DebugFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if err := df.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	return df.FormatSummaryStatistics(result.Records, result.Calculation)
+}
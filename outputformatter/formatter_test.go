@@ -102,6 +102,52 @@ func TestConsoleFormatter_FormatCurrentFare(t *testing.T) {
 	}
 }
 
+func TestConsoleFormatter_FormatCurrentFare_ShowTariff(t *testing.T) {
+	calculation := models.FareCalculation{
+		TotalFare:     decimal.NewFromInt(1000),
+		TariffName:    "jp-standard",
+		TariffVersion: "v1",
+	}
+
+	t.Run("disabled by default: no footer", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf)
+
+		if err := formatter.FormatCurrentFare(calculation); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if strings.Contains(buf.String(), "Tariff") {
+			t.Errorf("Expected no tariff footer by default, got: %s", buf.String())
+		}
+	})
+
+	t.Run("enabled: footer reflects the configured tariff", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.ShowTariff = true
+
+		if err := formatter.FormatCurrentFare(calculation); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "Tariff: jp-standard v1") {
+			t.Errorf("Expected output to contain %q, got: %s", "Tariff: jp-standard v1", buf.String())
+		}
+	})
+
+	t.Run("enabled but no tariff identity: no footer", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.ShowTariff = true
+
+		if err := formatter.FormatCurrentFare(models.FareCalculation{TotalFare: decimal.NewFromInt(1000)}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if strings.Contains(buf.String(), "Tariff") {
+			t.Errorf("Expected no tariff footer when TariffName is empty, got: %s", buf.String())
+		}
+	})
+}
+
 func TestConsoleFormatter_FormatRecords(t *testing.T) {
 	var buf bytes.Buffer
 	formatter := NewFormatterWithOutput(&buf)
@@ -173,6 +219,329 @@ func TestConsoleFormatter_FormatRecords(t *testing.T) {
 	})
 }
 
+func TestConsoleFormatter_FormatRecords_CollapseEqualDiffs(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+	formatter.CollapseEqualDiffs = true
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Four records (idle, diff 0.0) followed by one moving record (diff 2.0).
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromFloat(12345.0)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromFloat(12345.0)},
+		{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromFloat(12345.0)},
+		{Timestamp: baseTime.Add(3 * time.Minute), Distance: decimal.NewFromFloat(12345.0)},
+		{Timestamp: baseTime.Add(4 * time.Minute), Distance: decimal.NewFromFloat(12347.0)},
+	}
+
+	if err := formatter.FormatRecords(records); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	dataLines := lines[2:]
+
+	if len(dataLines) != 2 {
+		t.Fatalf("Expected the idle run to collapse to 1 row plus the moving record, got %d data lines: %v", len(dataLines), dataLines)
+	}
+
+	if !strings.Contains(dataLines[0], "2.0") {
+		t.Errorf("First data line should still be the uncollapsed moving record (diff 2.0), got: %s", dataLines[0])
+	}
+
+	collapsed := dataLines[1]
+	expectedContains := []string{"×4", "0.0", "12:00:00.000 - 12:03:00.000"}
+	for _, expected := range expectedContains {
+		if !strings.Contains(collapsed, expected) {
+			t.Errorf("Expected collapsed row to contain %q, got: %s", expected, collapsed)
+		}
+	}
+}
+
+func TestConsoleFormatter_FormatRecords_PageSize(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+	formatter.PageSize = 10
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	records := make([]models.DistanceRecord, 25)
+	for i := range records {
+		records[i] = models.DistanceRecord{
+			Timestamp: baseTime.Add(time.Duration(i) * time.Minute),
+			Distance:  decimal.NewFromInt(int64(12345000 + i)),
+		}
+	}
+
+	if err := formatter.FormatRecords(records); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	markerCount := strings.Count(output, "--- page")
+	if markerCount != 2 {
+		t.Errorf("Expected 2 page markers for 25 records at page size 10, got %d:\n%s", markerCount, output)
+	}
+	if !strings.Contains(output, "--- page 2 ---") || !strings.Contains(output, "--- page 3 ---") {
+		t.Errorf("Expected markers for pages 2 and 3, got:\n%s", output)
+	}
+}
+
+func TestConsoleFormatter_FormatRecords_TimeFormat(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+	formatter.TimeFormat = "15:04:05"
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 456000000, time.UTC)
+
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+	}
+
+	if err := formatter.FormatRecords(records); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "12:00:00.456") {
+		t.Errorf("Expected millisecond component to be trimmed, got:\n%s", output)
+	}
+	if !strings.Contains(output, "12:00:00") {
+		t.Errorf("Expected second-precision timestamp, got:\n%s", output)
+	}
+}
+
+func TestConsoleFormatter_ThousandsSeparator(t *testing.T) {
+	calculation := models.FareCalculation{
+		BaseFare:     decimal.NewFromInt(400),
+		DistanceFare: decimal.NewFromInt(1234167),
+		TotalFare:    decimal.NewFromInt(1234567),
+	}
+
+	t.Run("enabled: large fare renders with comma grouping", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.ThousandsSeparator = true
+
+		if err := formatter.FormatCurrentFare(calculation); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := strings.TrimSpace(buf.String())
+		if output != "1,234,567" {
+			t.Errorf("Expected %q, got %q", "1,234,567", output)
+		}
+	})
+
+	t.Run("disabled by default: fare renders unchanged", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+
+		if err := formatter.FormatCurrentFare(calculation); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := strings.TrimSpace(buf.String())
+		if output != "1234567" {
+			t.Errorf("Expected %q, got %q", "1234567", output)
+		}
+	})
+
+	t.Run("enabled: summary statistics totals are grouped", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.ThousandsSeparator = true
+
+		records := []models.DistanceRecord{
+			{Timestamp: time.Now(), Distance: decimal.NewFromInt(12345000)},
+		}
+		if err := formatter.FormatSummaryStatistics(records, calculation); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if !strings.Contains(output, "1,234,567 yen") {
+			t.Errorf("Expected grouped Total Fare line, got:\n%s", output)
+		}
+	})
+}
+
+func TestConsoleFormatter_TrimTrailingZeros(t *testing.T) {
+	records := []models.DistanceRecord{
+		{Timestamp: time.Now(), Distance: decimal.NewFromInt(12345)},
+	}
+	calculation := models.FareCalculation{TotalFare: decimal.NewFromInt(700)}
+
+	t.Run("disabled by default: distances keep fixed precision", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+
+		if err := formatter.FormatSummaryStatistics(records, calculation); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "12345.000 km") {
+			t.Errorf("Expected fixed-precision distance, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("enabled: whole-meter distances render without trailing zeros", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.TrimTrailingZeros = true
+
+		if err := formatter.FormatSummaryStatistics(records, calculation); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "12345 km") || strings.Contains(buf.String(), "12345.000") {
+			t.Errorf("Expected trimmed distance, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("enabled: fractional distances keep their significant digits", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.TrimTrailingZeros = true
+
+		fractional := []models.DistanceRecord{
+			{Timestamp: time.Now(), Distance: decimal.NewFromFloat(12345.25)},
+		}
+		if err := formatter.FormatSummaryStatistics(fractional, calculation); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "12345.25 km") {
+			t.Errorf("Expected trimmed fractional distance, got:\n%s", buf.String())
+		}
+	})
+}
+
+func TestConsoleFormatter_EmptyMessages(t *testing.T) {
+	t.Run("FormatRecords uses the overridden EmptyRecordsMessage", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.EmptyRecordsMessage = "記録がありません"
+
+		if err := formatter.FormatRecords([]models.DistanceRecord{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := strings.TrimSpace(buf.String())
+		if output != "記録がありません" {
+			t.Errorf("Expected %q, got %q", "記録がありません", output)
+		}
+	})
+
+	t.Run("FormatSummaryStatistics uses the overridden EmptyStatisticsMessage", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.EmptyStatisticsMessage = "統計データがありません"
+
+		if err := formatter.FormatSummaryStatistics([]models.DistanceRecord{}, models.FareCalculation{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := strings.TrimSpace(buf.String())
+		if output != "統計データがありません" {
+			t.Errorf("Expected %q, got %q", "統計データがありません", output)
+		}
+	})
+}
+
+func TestDebugFormatter_EmptyRecordsMessage(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewDebugFormatterWithOutput(&buf).(*DebugFormatter)
+	formatter.EmptyRecordsMessage = "no records"
+
+	if err := formatter.FormatRecords([]models.DistanceRecord{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := strings.TrimSpace(buf.String())
+	if output != "no records" {
+		t.Errorf("Expected %q, got %q", "no records", output)
+	}
+}
+
+func TestDebugFormatter_TrimTrailingZeros(t *testing.T) {
+	records := []models.DistanceRecord{
+		{Timestamp: time.Now(), Distance: decimal.NewFromInt(12345)},
+	}
+
+	t.Run("disabled by default: distances keep fixed precision", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewDebugFormatterWithOutput(&buf).(*DebugFormatter)
+
+		if err := formatter.FormatRecords(records); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "12345.000") {
+			t.Errorf("Expected fixed-precision distance, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("enabled: whole-meter distances render without trailing zeros", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewDebugFormatterWithOutput(&buf).(*DebugFormatter)
+		formatter.TrimTrailingZeros = true
+
+		if err := formatter.FormatRecords(records); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if strings.Contains(buf.String(), "12345.000") {
+			t.Errorf("Expected trimmed distance, got:\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "12345") {
+			t.Errorf("Expected distance value present, got:\n%s", buf.String())
+		}
+	})
+}
+
+func TestRoundFareForDisplay(t *testing.T) {
+	amount := decimal.NewFromFloat(1237.5)
+
+	tests := []struct {
+		name      string
+		mode      DisplayRoundingMode
+		increment decimal.Decimal
+		want      int64
+	}{
+		{"nearest with whole-unit increment", RoundNearest, decimal.NewFromInt(1), 1238},
+		{"up with whole-unit increment", RoundUp, decimal.NewFromInt(1), 1238},
+		{"down with whole-unit increment", RoundDown, decimal.NewFromInt(1), 1237},
+		{"nearest with a 10-unit increment", RoundNearest, decimal.NewFromInt(10), 1240},
+		{"up with a 10-unit increment", RoundUp, decimal.NewFromInt(10), 1240},
+		{"down with a 10-unit increment", RoundDown, decimal.NewFromInt(10), 1230},
+		{"zero increment falls back to whole-unit rounding", RoundNearest, decimal.Zero, 1238},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RoundFareForDisplay(amount, tt.mode, tt.increment)
+			if got != tt.want {
+				t.Errorf("RoundFareForDisplay(%s, mode=%d, increment=%s) = %d, want %d",
+					amount, tt.mode, tt.increment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactFormatter_FormatSummaryStatistics_EmptyRecords(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewCompactFormatterWithOutput(&buf)
+
+	if err := formatter.FormatSummaryStatistics(nil, models.FareCalculation{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := strings.TrimSpace(buf.String())
+	if output != "Records: 0, Fare: 0 yen" {
+		t.Errorf("Expected an empty-but-valid summary, got %q", output)
+	}
+}
+
 func TestConsoleFormatter_FormatProcessingResult(t *testing.T) {
 	var buf bytes.Buffer
 	formatter := NewFormatterWithOutput(&buf)
@@ -249,6 +618,140 @@ func TestConsoleFormatter_FormatProcessingResult(t *testing.T) {
 	})
 }
 
+func TestConsoleFormatter_FormatFullReport(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf)
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	result := models.ProcessingResult{
+		Records: []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromFloat(12345.0)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromFloat(12346.0)},
+		},
+		Calculation: models.FareCalculation{
+			BaseFare:     decimal.NewFromInt(400),
+			DistanceFare: decimal.NewFromInt(80),
+			TimeFare:     decimal.Zero,
+			TotalFare:    decimal.NewFromInt(480),
+		},
+		TotalTime: 250 * time.Millisecond,
+	}
+
+	if err := formatter.FormatFullReport(result); err != nil {
+		t.Fatalf("FormatFullReport() unexpected error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"Processing Summary", "12345", "Summary Statistics"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatFullReport() output missing %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestConsoleFormatter_FormatDiagnostics(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf)
+
+	diag := models.ProcessingDiagnostics{
+		TotalLines: 5,
+		BlankLines: 1,
+		ParseErrorsByType: map[string]int{
+			"format": 2,
+		},
+		ValidationErrorsByType: map[string]int{},
+	}
+
+	if err := formatter.(DiagnosticFormatter).FormatDiagnostics(diag); err != nil {
+		t.Fatalf("FormatDiagnostics() unexpected error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"Total lines read: 5", "Blank lines: 1", "format: 2", "Validation errors: none"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatDiagnostics() output missing %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestConsoleFormatter_SectionSeparator(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := models.ProcessingResult{
+		Records: []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromFloat(12345.0)},
+		},
+		Calculation: models.FareCalculation{
+			BaseFare:  decimal.NewFromInt(400),
+			TotalFare: decimal.NewFromInt(400),
+		},
+		TotalTime: 10 * time.Millisecond,
+	}
+
+	t.Run("custom separator appears between sections", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.SectionSeparator = "\n=== \n"
+
+		if err := formatter.FormatProcessingResult(result); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if !strings.Contains(output, "=== \nProcessing Summary:") {
+			t.Errorf("Expected custom separator before Processing Summary, got: %s", output)
+		}
+	})
+
+	t.Run("empty separator removes the gap", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.SectionSeparator = ""
+
+		if err := formatter.FormatProcessingResult(result); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if strings.Contains(output, "\n\nProcessing Summary") {
+			t.Errorf("Expected no blank line before Processing Summary, got: %s", output)
+		}
+		if !strings.Contains(output, "Processing Summary") {
+			t.Errorf("Expected output to still contain Processing Summary, got: %s", output)
+		}
+	})
+}
+
+func TestConsoleFormatter_MinorUnitScale(t *testing.T) {
+	total := decimal.NewFromFloat(1234.567)
+
+	t.Run("scale 0 rounds to whole yen", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+
+		if err := formatter.FormatCurrentFare(models.FareCalculation{TotalFare: total}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := buf.String(); got != "1235\n" {
+			t.Errorf("Expected %q, got %q", "1235\n", got)
+		}
+	})
+
+	t.Run("scale 2 rounds to cents", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewFormatterWithOutput(&buf).(*ConsoleFormatter)
+		formatter.MinorUnitScale = 2
+
+		if err := formatter.FormatCurrentFare(models.FareCalculation{TotalFare: total}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := buf.String(); got != "1234.57\n" {
+			t.Errorf("Expected %q, got %q", "1234.57\n", got)
+		}
+	})
+}
+
 func TestConsoleFormatter_FormatSummaryStatistics(t *testing.T) {
 	var buf bytes.Buffer
 	formatter := NewFormatterWithOutput(&buf)
@@ -508,6 +1011,75 @@ func TestDebugFormatter(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("FormatRecords respects TimeFormat", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewDebugFormatterWithOutput(&buf).(*DebugFormatter)
+		formatter.TimeFormat = "15:04:05"
+		baseTime := time.Date(2023, 1, 1, 12, 0, 0, 456000000, time.UTC)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromFloat(100.000)},
+		}
+
+		if err := formatter.FormatRecords(records); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if strings.Contains(output, "12:00:00.456") {
+			t.Errorf("Expected millisecond component to be trimmed, got: %s", output)
+		}
+		if !strings.Contains(output, "12:00:00") {
+			t.Errorf("Expected second-precision timestamp, got: %s", output)
+		}
+	})
+
+	t.Run("FormatRecordsWithLines shows source line column", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewDebugFormatterWithOutput(&buf).(*DebugFormatter)
+		formatter.ShowSourceLine = true
+		baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromFloat(100.000)},
+			{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromFloat(102.500)},
+		}
+		lines := []int{3, 7}
+
+		if err := formatter.FormatRecordsWithLines(records, lines); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if !strings.Contains(output, "Line") {
+			t.Errorf("Expected output to contain a Line column header, got: %s", output)
+		}
+
+		for _, rows := range []string{"0\t3\t", "1\t7\t"} {
+			if !strings.Contains(output, rows) {
+				t.Errorf("Expected output to contain row %q, got: %s", rows, output)
+			}
+		}
+	})
+
+	t.Run("FormatRecordsWithLines falls back without ShowSourceLine", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewDebugFormatterWithOutput(&buf).(*DebugFormatter)
+		baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromFloat(100.000)},
+		}
+
+		if err := formatter.FormatRecordsWithLines(records, []int{5}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if strings.Contains(buf.String(), "Line") {
+			t.Errorf("Expected no Line column when ShowSourceLine is disabled, got: %s", buf.String())
+		}
+	})
 }
 
 // Benchmark tests for performance validation
@@ -523,6 +1095,32 @@ func BenchmarkConsoleFormatter_FormatCurrentFare(b *testing.B) {
 	}
 }
 
+func TestSortByMileageDiffDescending(t *testing.T) {
+	records := []RecordWithDifference{
+		{Index: 0, MileageDiff: decimal.NewFromFloat(1.0)},
+		{Index: 1, MileageDiff: decimal.NewFromFloat(2.5)},
+		{Index: 2, MileageDiff: decimal.NewFromFloat(1.0)}, // ties with index 0
+		{Index: 3, MileageDiff: decimal.NewFromFloat(0.25)},
+		{Index: 4, MileageDiff: decimal.NewFromFloat(2.5)}, // ties with index 1
+	}
+
+	sortByMileageDiffDescending(records)
+
+	for i := 1; i < len(records); i++ {
+		if records[i].MileageDiff.GreaterThan(records[i-1].MileageDiff) {
+			t.Errorf("expected non-increasing MileageDiff, got %s before %s at position %d",
+				records[i-1].MileageDiff, records[i].MileageDiff, i)
+		}
+	}
+
+	wantDiffOrder := []string{"2.5", "2.5", "1", "1", "0.25"}
+	for i, want := range wantDiffOrder {
+		if records[i].MileageDiff.String() != want {
+			t.Errorf("position %d: expected MileageDiff %s, got %s", i, want, records[i].MileageDiff)
+		}
+	}
+}
+
 func BenchmarkConsoleFormatter_FormatRecords(b *testing.B) {
 	var buf bytes.Buffer
 	formatter := NewFormatterWithOutput(&buf)
@@ -544,6 +1142,116 @@ func BenchmarkConsoleFormatter_FormatRecords(b *testing.B) {
 	}
 }
 
+// BenchmarkConsoleFormatter_FormatRecords_Large exercises FormatRecords at a
+// scale (10k records) where sorting is no longer negligible next to the
+// table-writing cost. BenchmarkSortByMileageDiffDescending below isolates
+// just the sort and is the more direct evidence for its own optimization.
+func BenchmarkConsoleFormatter_FormatRecords_Large(b *testing.B) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf)
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	const recordCount = 10000
+	records := make([]models.DistanceRecord, recordCount)
+	distance := decimal.NewFromInt(12345000)
+	for i := range records {
+		// Vary the per-step increment so mileage diffs aren't all equal,
+		// exercising the sort rather than a single collapsed run.
+		distance = distance.Add(decimal.NewFromInt(int64(100 + i%37)))
+		records[i] = models.DistanceRecord{
+			Timestamp: baseTime.Add(time.Duration(i) * time.Second),
+			Distance:  distance,
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		formatter.FormatRecords(records)
+	}
+}
+
+// BenchmarkSortByMileageDiffDescending isolates just the sort step at the
+// same 10k-record scale as BenchmarkConsoleFormatter_FormatRecords_Large,
+// without the surrounding tabwriter formatting, so the optimization's own
+// cost is directly visible rather than diluted by output writing.
+func BenchmarkSortByMileageDiffDescending(b *testing.B) {
+	const recordCount = 10000
+	source := make([]RecordWithDifference, recordCount)
+	for i := range source {
+		source[i] = RecordWithDifference{
+			Index:       i,
+			MileageDiff: decimal.NewFromInt(int64(100 + i%37)),
+		}
+	}
+
+	records := make([]RecordWithDifference, recordCount)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(records, source)
+		sortByMileageDiffDescending(records)
+	}
+}
+
+func TestFormatFareComposition(t *testing.T) {
+	barLength := func(line string) int {
+		start := strings.Index(line, "[")
+		end := strings.Index(line, "]")
+		if start == -1 || end == -1 {
+			t.Fatalf("expected a bar delimited by [ ] in line %q", line)
+		}
+		return len(strings.TrimRight(line[start+1:end], " "))
+	}
+
+	t.Run("bar lengths are proportional to each component", func(t *testing.T) {
+		calculation := models.FareCalculation{
+			BaseFare:     decimal.NewFromInt(400),
+			DistanceFare: decimal.NewFromInt(400),
+			TimeFare:     decimal.NewFromInt(200),
+			TotalFare:    decimal.NewFromInt(1000),
+		}
+
+		var buf bytes.Buffer
+		if err := FormatFareComposition(calculation, &buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("Expected 3 lines (Base/Distance/Time), got %d: %q", len(lines), buf.String())
+		}
+
+		baseLen := barLength(lines[0])
+		distanceLen := barLength(lines[1])
+		timeLen := barLength(lines[2])
+
+		if baseLen != distanceLen {
+			t.Errorf("Expected Base and Distance bars to be equal length (both 40%%), got %d and %d", baseLen, distanceLen)
+		}
+		if timeLen >= baseLen {
+			t.Errorf("Expected Time bar (20%%) to be shorter than Base bar (40%%), got %d and %d", timeLen, baseLen)
+		}
+		if timeLen == 0 {
+			t.Error("Expected Time bar to be non-empty for a non-zero component")
+		}
+	})
+
+	t.Run("zero total fare does not divide by zero", func(t *testing.T) {
+		calculation := models.FareCalculation{TotalFare: decimal.Zero}
+
+		var buf bytes.Buffer
+		if err := FormatFareComposition(calculation, &buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+			if barLength(line) != 0 {
+				t.Errorf("Expected empty bar for zero total fare, got %q", line)
+			}
+		}
+	})
+}
+
 func BenchmarkCalculateStatistics(b *testing.B) {
 	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
 	calculation := models.FareCalculation{TotalFare: decimal.NewFromInt(1000)}
@@ -0,0 +1,79 @@
+package outputformatter
+
+import "golang-taxi-fare/models"
+
+// FuncFormatterConfig supplies the closures a FuncFormatter delegates to. A
+// nil field defaults to a no-op, so callers only need to populate the
+// methods they actually care about.
+type FuncFormatterConfig struct {
+	FormatCurrentFareFunc       func(calculation models.FareCalculation) error
+	FormatRecordsFunc           func(records []models.DistanceRecord) error
+	FormatProcessingResultFunc  func(result models.ProcessingResult) error
+	FormatSummaryStatisticsFunc func(records []models.DistanceRecord, calculation models.FareCalculation) error
+
+	// FormatFullReportFunc, if set, is called by FormatFullReport instead of
+	// its default composition of FormatProcessingResultFunc,
+	// FormatRecordsFunc, and FormatSummaryStatisticsFunc in order.
+	FormatFullReportFunc func(result models.ProcessingResult) error
+}
+
+// FuncFormatter implements the OutputFormatter interface by delegating each
+// method to a user-supplied closure, letting embedders implement ad-hoc
+// output formatting without defining a new type
+type FuncFormatter struct {
+	config FuncFormatterConfig
+}
+
+// NewFuncFormatter creates a FuncFormatter from config. Any nil function in
+// config is a no-op for that method.
+func NewFuncFormatter(config FuncFormatterConfig) OutputFormatter {
+	return &FuncFormatter{config: config}
+}
+
+// FormatCurrentFare delegates to config.FormatCurrentFareFunc, or does nothing if nil
+func (ff *FuncFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
+	if ff.config.FormatCurrentFareFunc == nil {
+		return nil
+	}
+	return ff.config.FormatCurrentFareFunc(calculation)
+}
+
+// FormatRecords delegates to config.FormatRecordsFunc, or does nothing if nil
+func (ff *FuncFormatter) FormatRecords(records []models.DistanceRecord) error {
+	if ff.config.FormatRecordsFunc == nil {
+		return nil
+	}
+	return ff.config.FormatRecordsFunc(records)
+}
+
+// FormatProcessingResult delegates to config.FormatProcessingResultFunc, or does nothing if nil
+func (ff *FuncFormatter) FormatProcessingResult(result models.ProcessingResult) error {
+	if ff.config.FormatProcessingResultFunc == nil {
+		return nil
+	}
+	return ff.config.FormatProcessingResultFunc(result)
+}
+
+// FormatSummaryStatistics delegates to config.FormatSummaryStatisticsFunc, or does nothing if nil
+func (ff *FuncFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
+	if ff.config.FormatSummaryStatisticsFunc == nil {
+		return nil
+	}
+	return ff.config.FormatSummaryStatisticsFunc(records, calculation)
+}
+
+// FormatFullReport delegates to config.FormatFullReportFunc if set,
+// otherwise calls FormatProcessingResult, FormatRecords, and
+// FormatSummaryStatistics in order.
+func (ff *FuncFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if ff.config.FormatFullReportFunc != nil {
+		return ff.config.FormatFullReportFunc(result)
+	}
+	if err := ff.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	if err := ff.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	return ff.FormatSummaryStatistics(result.Records, result.Calculation)
+}
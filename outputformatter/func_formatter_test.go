@@ -0,0 +1,86 @@
+package outputformatter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestNewFuncFormatter(t *testing.T) {
+	formatter := NewFuncFormatter(FuncFormatterConfig{})
+	if formatter == nil {
+		t.Error("Expected non-nil formatter")
+	}
+
+	if _, ok := formatter.(OutputFormatter); !ok {
+		t.Error("FuncFormatter should implement OutputFormatter interface")
+	}
+}
+
+func TestFuncFormatter_NilFuncsAreNoops(t *testing.T) {
+	formatter := NewFuncFormatter(FuncFormatterConfig{})
+
+	calculation := models.FareCalculation{TotalFare: decimal.NewFromInt(400)}
+	records := []models.DistanceRecord{{Timestamp: time.Now(), Distance: decimal.NewFromInt(1000)}}
+	result := models.ProcessingResult{Records: records, Calculation: calculation}
+
+	if err := formatter.FormatCurrentFare(calculation); err != nil {
+		t.Errorf("FormatCurrentFare() unexpected error = %v", err)
+	}
+	if err := formatter.FormatRecords(records); err != nil {
+		t.Errorf("FormatRecords() unexpected error = %v", err)
+	}
+	if err := formatter.FormatProcessingResult(result); err != nil {
+		t.Errorf("FormatProcessingResult() unexpected error = %v", err)
+	}
+	if err := formatter.FormatSummaryStatistics(records, calculation); err != nil {
+		t.Errorf("FormatSummaryStatistics() unexpected error = %v", err)
+	}
+}
+
+func TestFuncFormatter_FormatCurrentFareInvokesClosure(t *testing.T) {
+	var invoked models.FareCalculation
+	called := false
+
+	formatter := NewFuncFormatter(FuncFormatterConfig{
+		FormatCurrentFareFunc: func(calculation models.FareCalculation) error {
+			called = true
+			invoked = calculation
+			return nil
+		},
+	})
+
+	calculation := models.FareCalculation{
+		BaseFare:     decimal.NewFromInt(400),
+		DistanceFare: decimal.NewFromInt(80),
+		TotalFare:    decimal.NewFromInt(480),
+	}
+
+	if err := formatter.FormatCurrentFare(calculation); err != nil {
+		t.Fatalf("FormatCurrentFare() unexpected error = %v", err)
+	}
+
+	if !called {
+		t.Fatal("Expected FormatCurrentFareFunc to be invoked")
+	}
+
+	if !invoked.TotalFare.Equal(calculation.TotalFare) {
+		t.Errorf("Expected closure to receive TotalFare %s, got %s", calculation.TotalFare.String(), invoked.TotalFare.String())
+	}
+}
+
+func TestFuncFormatter_PropagatesError(t *testing.T) {
+	wantErr := errors.New("custom formatting error")
+	formatter := NewFuncFormatter(FuncFormatterConfig{
+		FormatCurrentFareFunc: func(calculation models.FareCalculation) error {
+			return wantErr
+		},
+	})
+
+	if err := formatter.FormatCurrentFare(models.FareCalculation{}); err != wantErr {
+		t.Errorf("FormatCurrentFare() error = %v, want %v", err, wantErr)
+	}
+}
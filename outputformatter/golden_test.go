@@ -0,0 +1,106 @@
+package outputformatter
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+// update regenerates golden files from the current formatter output when set,
+// e.g. `go test ./outputformatter/... -run TestGolden -update`
+var update = flag.Bool("update", false, "update golden files")
+
+// assertGolden compares actual against the contents of the golden file at
+// testdata/<name>, rewriting it in place when -update is passed
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			t.Fatalf("failed to update golden file %s: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s: %v (run with -update to create it)", path, err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("output does not match golden file %s\n--- expected ---\n%s\n--- actual ---\n%s",
+			path, expected, actual)
+	}
+}
+
+// goldenRecords returns a fixed, deterministic set of records for golden-file tests
+func goldenRecords() []models.DistanceRecord {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	return []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromFloat(12345678.0)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromFloat(12345778.5)},
+		{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromFloat(12345900.0)},
+	}
+}
+
+// goldenCalculation returns a fixed fare calculation for golden-file tests
+func goldenCalculation() models.FareCalculation {
+	return models.FareCalculation{
+		BaseFare:     decimal.NewFromInt(400),
+		DistanceFare: decimal.NewFromInt(120),
+		TimeFare:     decimal.Zero,
+		TotalFare:    decimal.NewFromInt(520),
+	}
+}
+
+func TestGolden_ConsoleFormatter_FormatRecords(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf)
+
+	if err := formatter.FormatRecords(goldenRecords()); err != nil {
+		t.Fatalf("FormatRecords() unexpected error = %v", err)
+	}
+
+	assertGolden(t, "console_format_records.golden", buf.Bytes())
+}
+
+func TestGolden_ConsoleFormatter_FormatSummaryStatistics(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOutput(&buf)
+
+	if err := formatter.FormatSummaryStatistics(goldenRecords(), goldenCalculation()); err != nil {
+		t.Fatalf("FormatSummaryStatistics() unexpected error = %v", err)
+	}
+
+	assertGolden(t, "console_format_summary.golden", buf.Bytes())
+}
+
+func TestGolden_CompactFormatter_FormatRecords(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewCompactFormatterWithOutput(&buf)
+
+	if err := formatter.FormatRecords(goldenRecords()); err != nil {
+		t.Fatalf("FormatRecords() unexpected error = %v", err)
+	}
+
+	assertGolden(t, "compact_format_records.golden", buf.Bytes())
+}
+
+func TestGolden_DebugFormatter_FormatRecords(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewDebugFormatterWithOutput(&buf)
+
+	if err := formatter.FormatRecords(goldenRecords()); err != nil {
+		t.Fatalf("FormatRecords() unexpected error = %v", err)
+	}
+
+	assertGolden(t, "debug_format_records.golden", buf.Bytes())
+}
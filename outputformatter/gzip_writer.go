@@ -0,0 +1,19 @@
+package outputformatter
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// NewGzipWriter wraps w in a gzip.Writer, letting any OutputFormatter
+// compress large result files on the fly instead of buffering the whole
+// output in memory before compressing it. Compose it with a formatter's
+// *WithOutput constructor, e.g.
+// NewJSONFormatterWithOutput(NewGzipWriter(file)). The returned
+// io.WriteCloser must be closed once writing is done to flush gzip's
+// footer; closing only the underlying w without closing this writer first
+// produces a truncated gzip stream. See Application.OutputCloser in the
+// root package for wiring this into the application's cleanup path.
+func NewGzipWriter(w io.Writer) io.WriteCloser {
+	return gzip.NewWriter(w)
+}
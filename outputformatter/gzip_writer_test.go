@@ -0,0 +1,51 @@
+package outputformatter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestNewGzipWriter_RoundTrip(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := NewGzipWriter(&compressed)
+
+	formatter := NewJSONFormatterWithOutput(gz)
+	calculation := models.FareCalculation{
+		BaseFare:     decimal.NewFromInt(400),
+		DistanceFare: decimal.NewFromInt(100),
+		TotalFare:    decimal.NewFromInt(500),
+	}
+	if err := formatter.FormatCurrentFare(calculation); err != nil {
+		t.Fatalf("FormatCurrentFare() error = %v", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip writer Close() error = %v", err)
+	}
+
+	reader, err := gzip.NewReader(&compressed)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("reading decompressed output: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(decompressed, &got); err != nil {
+		t.Fatalf("decompressed output is not valid JSON: %v\noutput: %s", err, decompressed)
+	}
+
+	if _, ok := got["total_fare"]; !ok {
+		t.Errorf("expected decompressed JSON to contain \"total_fare\", got %v", got)
+	}
+}
@@ -0,0 +1,206 @@
+package outputformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"golang-taxi-fare/models"
+)
+
+// JSONFormatter implements the OutputFormatter interface, emitting machine-readable
+// JSON output suitable for dashboards and other automated consumers
+type JSONFormatter struct {
+	output io.Writer
+
+	// MinorUnitScale controls how many decimal places FormatCurrentFare's
+	// scaled_total_fare field carries: 0 for currencies with no minor unit
+	// (yen, the default), 2 for cents-based currencies (USD). The full
+	// precision total_fare field is always included too.
+	MinorUnitScale int
+}
+
+// NewJSONFormatter creates a formatter with JSON output to stdout
+func NewJSONFormatter() OutputFormatter {
+	return NewJSONFormatterWithOutput(os.Stdout)
+}
+
+// NewJSONFormatterWithOutput creates a JSON formatter with custom output writer
+func NewJSONFormatterWithOutput(output io.Writer) OutputFormatter {
+	return &JSONFormatter{output: output, MinorUnitScale: 0}
+}
+
+// FormatCurrentFare formats the fare calculation as a JSON object. In addition
+// to the full-precision fields, it includes a scaled_total_fare string
+// rounded to MinorUnitScale decimal places, so currency-aware consumers don't
+// need to re-derive display rounding themselves.
+func (jf *JSONFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
+	payload := struct {
+		models.FareCalculation
+		MinorUnitScale  int    `json:"minor_unit_scale"`
+		ScaledTotalFare string `json:"scaled_total_fare"`
+	}{
+		FareCalculation: calculation,
+		MinorUnitScale:  jf.MinorUnitScale,
+		ScaledTotalFare: formatScaledAmount(calculation.TotalFare, jf.MinorUnitScale),
+	}
+	return jf.encode(payload)
+}
+
+// FormatRecords formats the records as a JSON array
+func (jf *JSONFormatter) FormatRecords(records []models.DistanceRecord) error {
+	return jf.encode(records)
+}
+
+// FormatProcessingResult formats the complete processing result as a JSON object
+func (jf *JSONFormatter) FormatProcessingResult(result models.ProcessingResult) error {
+	if result.Error == nil {
+		return jf.encode(result)
+	}
+	// An error interface value marshals as {}, so emit its message as the
+	// string the schema declares for "error".
+	return jf.encode(struct {
+		models.ProcessingResult
+		Error string `json:"error"`
+	}{result, result.Error.Error()})
+}
+
+// FormatSummaryStatistics marshals Statistics together with the fare breakdown as
+// a single JSON object, with decimal fields encoded as strings
+func (jf *JSONFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
+	stats := calculateStatistics(records, calculation)
+
+	payload := struct {
+		Statistics Statistics             `json:"statistics"`
+		Fare       models.FareCalculation `json:"fare"`
+	}{
+		Statistics: stats,
+		Fare:       calculation,
+	}
+
+	return jf.encode(payload)
+}
+
+// FormatFullReport writes the processing result, the records, and the
+// summary statistics as three consecutive JSON documents (an object, an
+// array, and an object, one per line), so a caller wanting
+// everything doesn't need to call all three separately.
+func (jf *JSONFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if err := jf.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	if err := jf.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	return jf.FormatSummaryStatistics(result.Records, result.Calculation)
+}
+
+// FormatError implements ErrorFormatter, writing err to the output as a JSON
+// object so a caller piping stdout can recover structured failure details on
+// the error path, before the process exits with the categorized code.
+func (jf *JSONFormatter) FormatError(err error) error {
+	return jf.encode(struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+// FormatDiagnostics implements DiagnosticFormatter, writing diag as a JSON
+// object so a caller piping stdout can recover line/error-type counts on an
+// insufficient-data failure, not just the bare error message.
+func (jf *JSONFormatter) FormatDiagnostics(diag models.ProcessingDiagnostics) error {
+	return jf.encode(diag)
+}
+
+// decimalSchemaProperty is the JSON Schema fragment shared by every
+// decimal.Decimal field: a string, since decimal.Decimal.MarshalJSON quotes
+// its output by default (decimal.MarshalJSONWithoutQuotes is false) to avoid
+// floating-point precision loss.
+var decimalSchemaProperty = map[string]interface{}{
+	"type":        "string",
+	"description": "decimal value encoded as a string to avoid precision loss",
+}
+
+// JSONSchema returns a JSON Schema (draft-07) document describing the JSON
+// object FormatProcessingResult emits, so consumers can validate against or
+// generate bindings for the output contract without reading this package's
+// source. The schema is static — it doesn't reflect jf's configuration
+// (e.g. MinorUnitScale only affects FormatCurrentFare's payload, which this
+// schema doesn't cover).
+func (jf *JSONFormatter) JSONSchema() string {
+	schema := map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title":   "ProcessingResult",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"records": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"timestamp": map[string]interface{}{
+							"type":   "string",
+							"format": "date-time",
+						},
+						"distance": decimalSchemaProperty,
+					},
+					"required": []string{"timestamp", "distance"},
+				},
+			},
+			"calculation": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"base_fare":      decimalSchemaProperty,
+					"distance_fare":  decimalSchemaProperty,
+					"time_fare":      decimalSchemaProperty,
+					"total_fare":     decimalSchemaProperty,
+					"rounding_delta": decimalSchemaProperty,
+					"tariff_name":    map[string]interface{}{"type": "string"},
+					"tariff_version": map[string]interface{}{"type": "string"},
+				},
+				"required": []string{"base_fare", "distance_fare", "time_fare", "total_fare", "rounding_delta"},
+			},
+			"total_time": map[string]interface{}{
+				"type":        "integer",
+				"description": "elapsed processing time in nanoseconds",
+			},
+			"error": map[string]interface{}{
+				"type": "string",
+			},
+		},
+		"required": []string{"records", "calculation", "total_time"},
+	}
+
+	// schema is a static literal built above; it always marshals cleanly.
+	data, _ := json.MarshalIndent(schema, "", "  ")
+	return string(data)
+}
+
+// encode writes v to the output as a single line of JSON
+func (jf *JSONFormatter) encode(v interface{}) error {
+	encoder := json.NewEncoder(jf.output)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("error encoding JSON output: %w", err)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for Statistics, controlling field names
+// and ensuring decimals are encoded as strings to avoid precision loss
+func (s Statistics) MarshalJSON() ([]byte, error) {
+	type statisticsJSON struct {
+		TotalRecords    int    `json:"total_records"`
+		TotalDistance   string `json:"total_distance_km"`
+		AverageDistance string `json:"average_distance_km"`
+		MinDistance     string `json:"min_distance_km"`
+		MaxDistance     string `json:"max_distance_km"`
+	}
+
+	return json.Marshal(statisticsJSON{
+		TotalRecords:    s.TotalRecords,
+		TotalDistance:   s.TotalDistance.StringFixed(3),
+		AverageDistance: s.AverageDistance.StringFixed(3),
+		MinDistance:     s.MinDistance.StringFixed(3),
+		MaxDistance:     s.MaxDistance.StringFixed(3),
+	})
+}
@@ -0,0 +1,181 @@
+package outputformatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestNewJSONFormatter(t *testing.T) {
+	formatter := NewJSONFormatter()
+	if formatter == nil {
+		t.Error("Expected non-nil formatter")
+	}
+
+	if _, ok := formatter.(OutputFormatter); !ok {
+		t.Error("JSONFormatter should implement OutputFormatter interface")
+	}
+}
+
+func TestJSONFormatter_JSONSchema(t *testing.T) {
+	formatter := NewJSONFormatterWithOutput(&bytes.Buffer{}).(*JSONFormatter)
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(formatter.JSONSchema()), &schema); err != nil {
+		t.Fatalf("JSONSchema() did not return valid JSON: %v", err)
+	}
+
+	if schema["$schema"] != "http://json-schema.org/draft-07/schema#" {
+		t.Errorf("expected draft-07 $schema, got %v", schema["$schema"])
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected top-level \"properties\" object")
+	}
+
+	for _, name := range []string{"records", "calculation", "total_time", "error"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("expected top-level property %q, not found", name)
+		}
+	}
+
+	calculation, ok := properties["calculation"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected \"calculation\" to be an object schema")
+	}
+	calcProperties, ok := calculation["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected \"calculation\" to declare properties")
+	}
+	for _, name := range []string{"base_fare", "distance_fare", "time_fare", "total_fare", "rounding_delta", "tariff_name", "tariff_version"} {
+		if _, ok := calcProperties[name]; !ok {
+			t.Errorf("expected calculation property %q, not found", name)
+		}
+	}
+}
+
+func TestJSONFormatter_FormatSummaryStatistics(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewJSONFormatterWithOutput(&buf)
+
+	records := []models.DistanceRecord{
+		{Timestamp: time.Now(), Distance: decimal.NewFromFloat(12345678.0)},
+		{Timestamp: time.Now(), Distance: decimal.NewFromFloat(12345778.0)},
+	}
+	calculation := models.FareCalculation{
+		BaseFare:     decimal.NewFromInt(400),
+		DistanceFare: decimal.NewFromInt(40),
+		TimeFare:     decimal.Zero,
+		TotalFare:    decimal.NewFromInt(440),
+	}
+
+	if err := formatter.FormatSummaryStatistics(records, calculation); err != nil {
+		t.Fatalf("FormatSummaryStatistics() unexpected error = %v", err)
+	}
+
+	var payload struct {
+		Statistics struct {
+			TotalRecords    int    `json:"total_records"`
+			TotalDistance   string `json:"total_distance_km"`
+			AverageDistance string `json:"average_distance_km"`
+			MinDistance     string `json:"min_distance_km"`
+			MaxDistance     string `json:"max_distance_km"`
+		} `json:"statistics"`
+		Fare struct {
+			TotalFare string `json:"total_fare"`
+		} `json:"fare"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("FormatSummaryStatistics() produced invalid JSON: %v\noutput: %s", err, buf.String())
+	}
+
+	if payload.Statistics.TotalRecords != 2 {
+		t.Errorf("total_records = %d, want 2", payload.Statistics.TotalRecords)
+	}
+	if payload.Fare.TotalFare != "440" {
+		t.Errorf("fare.total_fare = %q, want %q", payload.Fare.TotalFare, "440")
+	}
+}
+
+func TestJSONFormatter_FormatCurrentFareMinorUnitScale(t *testing.T) {
+	total := decimal.NewFromFloat(1234.567)
+
+	t.Run("scale 0 for yen", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewJSONFormatterWithOutput(&buf).(*JSONFormatter)
+
+		if err := formatter.FormatCurrentFare(models.FareCalculation{TotalFare: total}); err != nil {
+			t.Fatalf("FormatCurrentFare() unexpected error = %v", err)
+		}
+
+		var payload struct {
+			MinorUnitScale  int    `json:"minor_unit_scale"`
+			ScaledTotalFare string `json:"scaled_total_fare"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("FormatCurrentFare() produced invalid JSON: %v\noutput: %s", err, buf.String())
+		}
+
+		if payload.MinorUnitScale != 0 {
+			t.Errorf("minor_unit_scale = %d, want 0", payload.MinorUnitScale)
+		}
+		if payload.ScaledTotalFare != "1235" {
+			t.Errorf("scaled_total_fare = %q, want %q", payload.ScaledTotalFare, "1235")
+		}
+	})
+
+	t.Run("scale 2 for dollars", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := NewJSONFormatterWithOutput(&buf).(*JSONFormatter)
+		formatter.MinorUnitScale = 2
+
+		if err := formatter.FormatCurrentFare(models.FareCalculation{TotalFare: total}); err != nil {
+			t.Fatalf("FormatCurrentFare() unexpected error = %v", err)
+		}
+
+		var payload struct {
+			MinorUnitScale  int    `json:"minor_unit_scale"`
+			ScaledTotalFare string `json:"scaled_total_fare"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("FormatCurrentFare() produced invalid JSON: %v\noutput: %s", err, buf.String())
+		}
+
+		if payload.MinorUnitScale != 2 {
+			t.Errorf("minor_unit_scale = %d, want 2", payload.MinorUnitScale)
+		}
+		if payload.ScaledTotalFare != "1234.57" {
+			t.Errorf("scaled_total_fare = %q, want %q", payload.ScaledTotalFare, "1234.57")
+		}
+	})
+}
+
+func TestStatistics_MarshalJSON(t *testing.T) {
+	stats := Statistics{
+		TotalRecords:    3,
+		TotalDistance:   decimal.NewFromFloat(1.5),
+		AverageDistance: decimal.NewFromFloat(0.5),
+		MinDistance:     decimal.Zero,
+		MaxDistance:     decimal.NewFromFloat(1.5),
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("MarshalJSON() unexpected error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Marshaled Statistics is not valid JSON: %v", err)
+	}
+
+	if _, ok := decoded["total_distance_km"].(string); !ok {
+		t.Errorf("expected total_distance_km to be encoded as a string, got %T", decoded["total_distance_km"])
+	}
+}
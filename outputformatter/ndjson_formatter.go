@@ -0,0 +1,126 @@
+package outputformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+// NDJSONFormatter implements the OutputFormatter interface, emitting
+// newline-delimited JSON (NDJSON) suitable for streaming into log ingestion
+// pipelines: FormatRecords writes one JSON object per record per line,
+// rather than JSONFormatter's single JSON array document.
+type NDJSONFormatter struct {
+	output io.Writer
+}
+
+// NewNDJSONFormatter creates a formatter with NDJSON output to stdout
+func NewNDJSONFormatter() OutputFormatter {
+	return NewNDJSONFormatterWithOutput(os.Stdout)
+}
+
+// NewNDJSONFormatterWithOutput creates an NDJSON formatter with custom output writer
+func NewNDJSONFormatterWithOutput(output io.Writer) OutputFormatter {
+	return &NDJSONFormatter{output: output}
+}
+
+// ndjsonRecord is the per-line record shape emitted by FormatRecords
+type ndjsonRecord struct {
+	Index       int    `json:"index"`
+	Timestamp   string `json:"timestamp"`
+	Distance    string `json:"distance"`
+	MileageDiff string `json:"mileage_diff"`
+}
+
+// FormatCurrentFare formats the fare calculation as a single NDJSON line
+func (nf *NDJSONFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
+	return nf.encode(calculation)
+}
+
+// FormatRecords emits one JSON object per record, one per line. MileageDiff
+// is the distance delta from the previous record ("0" for the first record).
+func (nf *NDJSONFormatter) FormatRecords(records []models.DistanceRecord) error {
+	previous := models.DistanceRecord{}
+	for i, record := range records {
+		diff := decimal.Zero
+		if i > 0 {
+			diff = record.Distance.Sub(previous.Distance)
+		}
+
+		line := ndjsonRecord{
+			Index:       i,
+			Timestamp:   record.Timestamp.Format("2006-01-02T15:04:05.000Z07:00"),
+			Distance:    record.Distance.String(),
+			MileageDiff: diff.String(),
+		}
+
+		if err := nf.encode(line); err != nil {
+			return err
+		}
+		previous = record
+	}
+	return nil
+}
+
+// FormatProcessingResult emits a final NDJSON summary object for the processing result
+func (nf *NDJSONFormatter) FormatProcessingResult(result models.ProcessingResult) error {
+	return nf.encode(result)
+}
+
+// FormatSummaryStatistics emits a single NDJSON line with summary statistics and fare breakdown
+func (nf *NDJSONFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
+	stats := calculateStatistics(records, calculation)
+
+	payload := struct {
+		Statistics Statistics             `json:"statistics"`
+		Fare       models.FareCalculation `json:"fare"`
+	}{
+		Statistics: stats,
+		Fare:       calculation,
+	}
+
+	return nf.encode(payload)
+}
+
+// FormatFullReport emits the per-record NDJSON lines followed by the
+// processing-result and summary-statistics lines, so a caller wanting
+// everything doesn't need to call all three separately.
+func (nf *NDJSONFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if err := nf.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	if err := nf.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	return nf.FormatSummaryStatistics(result.Records, result.Calculation)
+}
+
+// FormatError implements ErrorFormatter, writing err to the output as a
+// single NDJSON line so a caller piping stdout can recover structured
+// failure details on the error path, before the process exits with the
+// categorized code.
+func (nf *NDJSONFormatter) FormatError(err error) error {
+	return nf.encode(struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+// FormatDiagnostics implements DiagnosticFormatter, writing diag as a single
+// NDJSON line so a caller piping stdout can recover line/error-type counts
+// on an insufficient-data failure, not just the bare error message.
+func (nf *NDJSONFormatter) FormatDiagnostics(diag models.ProcessingDiagnostics) error {
+	return nf.encode(diag)
+}
+
+// encode writes v to the output as a single line of JSON
+func (nf *NDJSONFormatter) encode(v interface{}) error {
+	encoder := json.NewEncoder(nf.output)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("error encoding NDJSON output: %w", err)
+	}
+	return nil
+}
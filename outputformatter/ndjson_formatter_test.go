@@ -0,0 +1,92 @@
+package outputformatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestNewNDJSONFormatter(t *testing.T) {
+	formatter := NewNDJSONFormatter()
+	if formatter == nil {
+		t.Error("Expected non-nil formatter")
+	}
+
+	if _, ok := formatter.(OutputFormatter); !ok {
+		t.Error("NDJSONFormatter should implement OutputFormatter interface")
+	}
+}
+
+func TestNDJSONFormatter_FormatRecords(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewNDJSONFormatterWithOutput(&buf)
+
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []models.DistanceRecord{
+		{Timestamp: baseTime, Distance: decimal.NewFromInt(1000)},
+		{Timestamp: baseTime.Add(time.Minute), Distance: decimal.NewFromInt(1500)},
+		{Timestamp: baseTime.Add(2 * time.Minute), Distance: decimal.NewFromInt(2200)},
+	}
+
+	if err := formatter.FormatRecords(records); err != nil {
+		t.Fatalf("FormatRecords() unexpected error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != len(records) {
+		t.Fatalf("Expected %d lines, got %d", len(records), len(lines))
+	}
+
+	wantDiffs := []string{"0", "500", "700"}
+	for i, line := range lines {
+		var decoded struct {
+			Index       int    `json:"index"`
+			Timestamp   string `json:"timestamp"`
+			Distance    string `json:"distance"`
+			MileageDiff string `json:"mileage_diff"`
+		}
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("Line %d failed to unmarshal independently: %v", i, err)
+		}
+		if decoded.Index != i {
+			t.Errorf("Line %d: Index = %d, want %d", i, decoded.Index, i)
+		}
+		if decoded.Distance != records[i].Distance.String() {
+			t.Errorf("Line %d: Distance = %s, want %s", i, decoded.Distance, records[i].Distance.String())
+		}
+		if decoded.MileageDiff != wantDiffs[i] {
+			t.Errorf("Line %d: MileageDiff = %s, want %s", i, decoded.MileageDiff, wantDiffs[i])
+		}
+	}
+}
+
+func TestNDJSONFormatter_FormatProcessingResult(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewNDJSONFormatterWithOutput(&buf)
+
+	result := models.ProcessingResult{
+		Records: []models.DistanceRecord{
+			{Timestamp: time.Now(), Distance: decimal.NewFromInt(1000)},
+		},
+		Calculation: models.FareCalculation{TotalFare: decimal.NewFromInt(400)},
+	}
+
+	if err := formatter.FormatProcessingResult(result); err != nil {
+		t.Fatalf("FormatProcessingResult() unexpected error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected a single summary line, got %d", len(lines))
+	}
+
+	var decoded models.ProcessingResult
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+		t.Fatalf("Summary line failed to unmarshal: %v", err)
+	}
+}
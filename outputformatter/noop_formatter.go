@@ -0,0 +1,38 @@
+package outputformatter
+
+import "golang-taxi-fare/models"
+
+// NoopFormatter implements the OutputFormatter interface by discarding all
+// output. It is used for UNIX-quiet modes where only the exit code matters
+// and a successful run should produce no stdout.
+type NoopFormatter struct{}
+
+// NewNoopFormatter creates a formatter that discards all output
+func NewNoopFormatter() OutputFormatter {
+	return &NoopFormatter{}
+}
+
+// FormatCurrentFare does nothing
+func (nf *NoopFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
+	return nil
+}
+
+// FormatRecords does nothing
+func (nf *NoopFormatter) FormatRecords(records []models.DistanceRecord) error {
+	return nil
+}
+
+// FormatProcessingResult does nothing
+func (nf *NoopFormatter) FormatProcessingResult(result models.ProcessingResult) error {
+	return nil
+}
+
+// FormatSummaryStatistics does nothing
+func (nf *NoopFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
+	return nil
+}
+
+// FormatFullReport does nothing
+func (nf *NoopFormatter) FormatFullReport(result models.ProcessingResult) error {
+	return nil
+}
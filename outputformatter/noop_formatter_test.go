@@ -0,0 +1,41 @@
+package outputformatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestNewNoopFormatter(t *testing.T) {
+	formatter := NewNoopFormatter()
+	if formatter == nil {
+		t.Error("Expected non-nil formatter")
+	}
+
+	if _, ok := formatter.(OutputFormatter); !ok {
+		t.Error("NoopFormatter should implement OutputFormatter interface")
+	}
+}
+
+func TestNoopFormatter_AllMethodsReturnNil(t *testing.T) {
+	formatter := NewNoopFormatter()
+
+	calculation := models.FareCalculation{TotalFare: decimal.NewFromInt(400)}
+	records := []models.DistanceRecord{{Timestamp: time.Now(), Distance: decimal.NewFromInt(1000)}}
+	result := models.ProcessingResult{Records: records, Calculation: calculation}
+
+	if err := formatter.FormatCurrentFare(calculation); err != nil {
+		t.Errorf("FormatCurrentFare() unexpected error = %v", err)
+	}
+	if err := formatter.FormatRecords(records); err != nil {
+		t.Errorf("FormatRecords() unexpected error = %v", err)
+	}
+	if err := formatter.FormatProcessingResult(result); err != nil {
+		t.Errorf("FormatProcessingResult() unexpected error = %v", err)
+	}
+	if err := formatter.FormatSummaryStatistics(records, calculation); err != nil {
+		t.Errorf("FormatSummaryStatistics() unexpected error = %v", err)
+	}
+}
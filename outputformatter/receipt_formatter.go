@@ -0,0 +1,116 @@
+package outputformatter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+// ReceiptFormatter implements the OutputFormatter interface, emitting a
+// passenger-facing receipt with the trip time span, distance, average speed,
+// and an itemized fare breakdown
+type ReceiptFormatter struct {
+	output io.Writer
+}
+
+// NewReceiptFormatter creates a formatter with receipt output to stdout
+func NewReceiptFormatter() OutputFormatter {
+	return NewReceiptFormatterWithOutput(os.Stdout)
+}
+
+// NewReceiptFormatterWithOutput creates a receipt formatter with custom output writer
+func NewReceiptFormatterWithOutput(output io.Writer) OutputFormatter {
+	return &ReceiptFormatter{output: output}
+}
+
+// FormatCurrentFare formats just the total fare line of the receipt
+func (rf *ReceiptFormatter) FormatCurrentFare(calculation models.FareCalculation) error {
+	fmt.Fprintf(rf.output, "Total Fare: %d yen\n", RoundFareForDisplay(calculation.TotalFare, RoundNearest, decimal.NewFromInt(1)))
+	return nil
+}
+
+// FormatRecords formats a brief record count line
+func (rf *ReceiptFormatter) FormatRecords(records []models.DistanceRecord) error {
+	fmt.Fprintf(rf.output, "Records: %d\n", len(records))
+	return nil
+}
+
+// FormatProcessingResult emits the full passenger receipt: trip start/end
+// time, total distance, average speed, and an itemized fare breakdown with
+// total
+func (rf *ReceiptFormatter) FormatProcessingResult(result models.ProcessingResult) error {
+	if result.Error != nil {
+		fmt.Fprintf(rf.output, "Receipt unavailable: %v\n", result.Error)
+		return nil
+	}
+
+	if !result.IsValid() {
+		fmt.Fprint(rf.output, "Receipt unavailable: invalid processing result\n")
+		return nil
+	}
+
+	records := result.Records
+	calculation := result.Calculation
+
+	tripStart := records[0].Timestamp
+	tripEnd := records[len(records)-1].Timestamp
+	tripDistanceKm := records[len(records)-1].Distance.Sub(records[0].Distance).Div(decimal.NewFromInt(1000))
+
+	fmt.Fprintln(rf.output, "===== Trip Receipt =====")
+	fmt.Fprintf(rf.output, "Start:    %s\n", tripStart.Format("15:04:05.000"))
+	fmt.Fprintf(rf.output, "End:      %s\n", tripEnd.Format("15:04:05.000"))
+	fmt.Fprintf(rf.output, "Duration: %v\n", result.TotalTime)
+	fmt.Fprintf(rf.output, "Distance: %s km\n", tripDistanceKm.StringFixed(3))
+
+	if avgSpeed, ok := averageSpeedKmh(tripDistanceKm, tripEnd.Sub(tripStart)); ok {
+		fmt.Fprintf(rf.output, "Average Speed: %s km/h\n", avgSpeed.StringFixed(1))
+	}
+
+	fmt.Fprintln(rf.output, "-------------------------")
+	// Note: TimeFare is always zero in this calculator's model. FareCalculation
+	// has no night-surcharge, service-charge or service-fee field: the
+	// calculator folds those into TotalFare only, so when any of them applies
+	// the itemized lines below do not add up to Total.
+	fmt.Fprintf(rf.output, "Base Fare:     %d yen\n", RoundFareForDisplay(calculation.BaseFare, RoundNearest, decimal.NewFromInt(1)))
+	fmt.Fprintf(rf.output, "Distance Fare: %d yen\n", RoundFareForDisplay(calculation.DistanceFare, RoundNearest, decimal.NewFromInt(1)))
+	fmt.Fprintf(rf.output, "Time Fare:     %d yen\n", RoundFareForDisplay(calculation.TimeFare, RoundNearest, decimal.NewFromInt(1)))
+	fmt.Fprintln(rf.output, "-------------------------")
+	fmt.Fprintf(rf.output, "Total:         %d yen\n", RoundFareForDisplay(calculation.TotalFare, RoundNearest, decimal.NewFromInt(1)))
+	fmt.Fprintln(rf.output, "=========================")
+
+	return nil
+}
+
+// FormatSummaryStatistics formats a brief statistics line for the receipt
+func (rf *ReceiptFormatter) FormatSummaryStatistics(records []models.DistanceRecord, calculation models.FareCalculation) error {
+	stats := calculateStatistics(records, calculation)
+	fmt.Fprintf(rf.output, "Records: %d, Total Fare: %d yen\n", stats.TotalRecords, RoundFareForDisplay(calculation.TotalFare, RoundNearest, decimal.NewFromInt(1)))
+	return nil
+}
+
+// FormatFullReport prints the full passenger receipt followed by the brief
+// records and statistics lines, so a caller wanting everything doesn't need
+// to call all three separately.
+func (rf *ReceiptFormatter) FormatFullReport(result models.ProcessingResult) error {
+	if err := rf.FormatProcessingResult(result); err != nil {
+		return err
+	}
+	if err := rf.FormatRecords(result.Records); err != nil {
+		return err
+	}
+	return rf.FormatSummaryStatistics(result.Records, result.Calculation)
+}
+
+// averageSpeedKmh computes the average speed in km/h for a trip, returning
+// false when duration is non-positive (average speed is undefined)
+func averageSpeedKmh(distanceKm decimal.Decimal, duration time.Duration) (decimal.Decimal, bool) {
+	if duration <= 0 {
+		return decimal.Zero, false
+	}
+	hours := decimal.NewFromFloat(duration.Hours())
+	return distanceKm.Div(hours), true
+}
@@ -0,0 +1,102 @@
+package outputformatter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang-taxi-fare/models"
+)
+
+func TestNewReceiptFormatter(t *testing.T) {
+	formatter := NewReceiptFormatter()
+	if formatter == nil {
+		t.Error("Expected non-nil formatter")
+	}
+
+	if _, ok := formatter.(OutputFormatter); !ok {
+		t.Error("ReceiptFormatter should implement OutputFormatter interface")
+	}
+}
+
+func TestReceiptFormatter_FormatProcessingResult(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewReceiptFormatterWithOutput(&buf)
+
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := models.ProcessingResult{
+		Records: []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(12345000)},
+			{Timestamp: baseTime.Add(10 * time.Minute), Distance: decimal.NewFromInt(12347000)},
+		},
+		Calculation: models.FareCalculation{
+			BaseFare:     decimal.NewFromInt(400),
+			DistanceFare: decimal.NewFromInt(120),
+			TimeFare:     decimal.Zero,
+			TotalFare:    decimal.NewFromInt(520),
+		},
+		TotalTime: 10 * time.Minute,
+	}
+
+	if err := formatter.FormatProcessingResult(result); err != nil {
+		t.Fatalf("FormatProcessingResult() unexpected error = %v", err)
+	}
+
+	output := buf.String()
+
+	expectedFields := []string{
+		"Start:", "12:00:00.000",
+		"End:", "12:10:00.000",
+		"Distance:", "2.000 km",
+		"Average Speed:",
+		"Base Fare:", "400 yen",
+		"Distance Fare:", "120 yen",
+		"Time Fare:", "0 yen",
+		"Total:", "520 yen",
+	}
+
+	for _, field := range expectedFields {
+		if !strings.Contains(output, field) {
+			t.Errorf("Expected receipt output to contain %q, got:\n%s", field, output)
+		}
+	}
+}
+
+func TestReceiptFormatter_FormatProcessingResultWithError(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewReceiptFormatterWithOutput(&buf)
+
+	result := models.ProcessingResult{
+		Error: errors.New("simulated processing error"),
+	}
+
+	if err := formatter.FormatProcessingResult(result); err != nil {
+		t.Fatalf("FormatProcessingResult() unexpected error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Receipt unavailable") {
+		t.Errorf("Expected output to report unavailable receipt, got: %s", buf.String())
+	}
+}
+
+func TestAverageSpeedKmh(t *testing.T) {
+	t.Run("computes speed for positive duration", func(t *testing.T) {
+		speed, ok := averageSpeedKmh(decimal.NewFromInt(10), time.Hour)
+		if !ok {
+			t.Fatal("Expected average speed to be defined")
+		}
+		if !speed.Equal(decimal.NewFromInt(10)) {
+			t.Errorf("Expected speed 10, got %s", speed.String())
+		}
+	})
+
+	t.Run("undefined for zero duration", func(t *testing.T) {
+		_, ok := averageSpeedKmh(decimal.NewFromInt(10), 0)
+		if ok {
+			t.Error("Expected average speed to be undefined for zero duration")
+		}
+	})
+}
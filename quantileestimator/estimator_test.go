@@ -0,0 +1,97 @@
+package quantileestimator
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// exactQuantile computes the exact q-quantile of values via sorting, using
+// the same floor(q*(n-1)) index convention (no interpolation) as
+// P2Estimator's Value() fallback, for a fair comparison in tests.
+func exactQuantile(values []float64, q float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func TestP2Estimator_ApproximatesExactQuantile(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	values := make([]float64, 2000)
+	for i := range values {
+		values[i] = rng.NormFloat64()*50 + 200 // mean 200, stddev 50
+	}
+
+	cases := []struct {
+		name      string
+		q         float64
+		tolerance float64 // max allowed absolute difference from the exact quantile
+	}{
+		{"P50", 0.5, 5.0},
+		{"P95", 0.95, 10.0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			estimator := NewQuantileEstimator(c.q)
+			for _, v := range values {
+				estimator.Add(decimal.NewFromFloat(v))
+			}
+
+			got, _ := estimator.Value().Float64()
+			want := exactQuantile(values, c.q)
+
+			if diff := math.Abs(got - want); diff > c.tolerance {
+				t.Errorf("%s estimate %.2f differs from exact %.2f by %.2f, exceeding tolerance %.2f",
+					c.name, got, want, diff, c.tolerance)
+			}
+		})
+	}
+}
+
+func TestP2Estimator_FewerThanFiveSamples(t *testing.T) {
+	estimator := NewQuantileEstimator(0.5)
+
+	values := []decimal.Decimal{
+		decimal.NewFromInt(10),
+		decimal.NewFromInt(30),
+		decimal.NewFromInt(20),
+	}
+	for _, v := range values {
+		estimator.Add(v)
+	}
+
+	// With 3 samples sorted [10, 20, 30], the median index is 1 -> 20.
+	expected := decimal.NewFromInt(20)
+	if !estimator.Value().Equal(expected) {
+		t.Errorf("Expected fallback exact median %s, got %s", expected.String(), estimator.Value().String())
+	}
+}
+
+func TestP2Estimator_NoSamples(t *testing.T) {
+	estimator := NewQuantileEstimator(0.5)
+	if !estimator.Value().IsZero() {
+		t.Errorf("Expected zero value before any samples, got %s", estimator.Value().String())
+	}
+}
+
+func TestP2Estimator_MonotonicInput(t *testing.T) {
+	// A strictly increasing stream is a reasonable sanity check that the
+	// estimate stays within the observed range even on a non-random input.
+	estimator := NewQuantileEstimator(0.5)
+	for i := 1; i <= 1000; i++ {
+		estimator.Add(decimal.NewFromInt(int64(i)))
+	}
+
+	value, _ := estimator.Value().Float64()
+	if value < 1 || value > 1000 {
+		t.Errorf("Expected median estimate within [1, 1000], got %v", value)
+	}
+	if math.Abs(value-500) > 50 {
+		t.Errorf("Expected median estimate near 500 for a uniform 1..1000 stream, got %v", value)
+	}
+}
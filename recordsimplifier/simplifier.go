@@ -0,0 +1,56 @@
+// Package recordsimplifier reduces dense DistanceRecord sequences for
+// plotting by dropping intermediate records that add little new distance
+// information.
+package recordsimplifier
+
+import (
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// Simplifier defines the interface for reducing a DistanceRecord sequence
+type Simplifier interface {
+	// Simplify drops intermediate records whose cumulative distance since the
+	// last kept record is below minSegmentDistance, always keeping the first
+	// and last record.
+	Simplify(records []models.DistanceRecord, minSegmentDistance decimal.Decimal) []models.DistanceRecord
+}
+
+// DistanceSimplifier implements Simplifier with a 1D Douglas-Peucker-style
+// reduction on the distance axis: a record is dropped whenever it lies less
+// than minSegmentDistance beyond the most recently kept record (including
+// any record whose distance is below it).
+type DistanceSimplifier struct{}
+
+// NewSimplifier creates a new DistanceSimplifier
+func NewSimplifier() Simplifier {
+	return &DistanceSimplifier{}
+}
+
+// Simplify drops intermediate records whose cumulative distance since the
+// last kept record is below minSegmentDistance, always keeping the first and
+// last record. farecalculator.CalculateFromRecords prices the maximum minus
+// the minimum distance by default, which for a non-decreasing sequence is the
+// last minus the first record, so simplifying such a sequence before
+// computing its fare does not change the result. For a sequence whose
+// distance dips, a dropped intermediate record can be the maximum or
+// minimum, and the fare can change.
+func (ds *DistanceSimplifier) Simplify(records []models.DistanceRecord, minSegmentDistance decimal.Decimal) []models.DistanceRecord {
+	if len(records) <= 2 {
+		return records
+	}
+
+	simplified := make([]models.DistanceRecord, 0, len(records))
+	simplified = append(simplified, records[0])
+	lastKept := records[0]
+
+	for i := 1; i < len(records)-1; i++ {
+		if records[i].Distance.Sub(lastKept.Distance).GreaterThanOrEqual(minSegmentDistance) {
+			simplified = append(simplified, records[i])
+			lastKept = records[i]
+		}
+	}
+
+	return append(simplified, records[len(records)-1])
+}
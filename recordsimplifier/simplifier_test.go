@@ -0,0 +1,73 @@
+package recordsimplifier
+
+import (
+	"testing"
+	"time"
+
+	"golang-taxi-fare/models"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestNewSimplifier(t *testing.T) {
+	simplifier := NewSimplifier()
+	if simplifier == nil {
+		t.Error("Expected non-nil simplifier")
+	}
+
+	if _, ok := simplifier.(Simplifier); !ok {
+		t.Error("DistanceSimplifier should implement Simplifier interface")
+	}
+}
+
+func TestDistanceSimplifier_Simplify(t *testing.T) {
+	baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("dense series is reduced, endpoints kept", func(t *testing.T) {
+		simplifier := NewSimplifier()
+
+		records := make([]models.DistanceRecord, 0, 21)
+		for i := 0; i <= 20; i++ {
+			records = append(records, models.DistanceRecord{
+				Timestamp: baseTime.Add(time.Duration(i) * time.Second),
+				Distance:  decimal.NewFromInt(int64(i * 10)), // 0, 10, 20, ..., 200
+			})
+		}
+
+		result := simplifier.Simplify(records, decimal.NewFromInt(100))
+
+		if len(result) >= len(records) {
+			t.Fatalf("Expected a reduction, got %d records from %d", len(result), len(records))
+		}
+
+		if !result[0].Distance.Equal(records[0].Distance) {
+			t.Errorf("Expected first record preserved, got distance %s", result[0].Distance.String())
+		}
+
+		last := records[len(records)-1]
+		if !result[len(result)-1].Distance.Equal(last.Distance) {
+			t.Errorf("Expected last record preserved, got distance %s", result[len(result)-1].Distance.String())
+		}
+
+		for i := 1; i < len(result); i++ {
+			diff := result[i].Distance.Sub(result[i-1].Distance)
+			if diff.LessThan(decimal.NewFromInt(100)) && i != len(result)-1 {
+				t.Errorf("Kept records %d and %d are only %s apart, below threshold", i-1, i, diff.String())
+			}
+		}
+	})
+
+	t.Run("two or fewer records returned unchanged", func(t *testing.T) {
+		simplifier := NewSimplifier()
+
+		records := []models.DistanceRecord{
+			{Timestamp: baseTime, Distance: decimal.NewFromInt(0)},
+			{Timestamp: baseTime.Add(time.Second), Distance: decimal.NewFromInt(5)},
+		}
+
+		result := simplifier.Simplify(records, decimal.NewFromInt(100))
+		if len(result) != 2 {
+			t.Errorf("Expected 2 records unchanged, got %d", len(result))
+		}
+	})
+}